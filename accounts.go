@@ -0,0 +1,126 @@
+package drip
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrUnknownAccount is returned by AccountRegistry.Get if no account is registered for a name.
+var ErrUnknownAccount = fmt.Errorf("unknown drip account")
+
+// Account is a Drip account the api key has access to.
+type Account struct {
+	ID                      string    `json:"id,omitempty"`
+	HREF                    string    `json:"href,omitempty"`
+	Name                    string    `json:"name,omitempty"`
+	URL                     string    `json:"url,omitempty"`
+	DefaultFromName         string    `json:"default_from_name,omitempty"`
+	DefaultFromEmail        string    `json:"default_from_email,omitempty"`
+	DefaultPostalAddress    string    `json:"default_postal_address,omitempty"`
+	PrimaryEmail            string    `json:"primary_email,omitempty"`
+	EnableThirdPartyCookies bool      `json:"enable_third_party_cookies,omitempty"`
+	PhoneNumber             string    `json:"phone_number,omitempty"`
+	CreatedAt               time.Time `json:"created_at,omitempty"`
+}
+
+// AccountsResp is a response recieved with accounts in it.
+type AccountsResp struct {
+	StatusCode int         `json:"status_code,omitempty"`
+	Links      Links       `json:"links,omitempty"`
+	Accounts   []*Account  `json:"accounts,omitempty"`
+	Errors     []CodeError `json:"errors,omitempty"`
+}
+
+// NewWithoutAccount returns a new Client that is not bound to an account.
+// Use it with ListAccounts to discover account IDs and ForAccount to get an account scoped Client;
+// account scoped methods return ErrBadAccountID.
+func NewWithoutAccount(apiKey string) (*Client, error) {
+	if apiKey == "" {
+		return nil, ErrBadAPIKey
+	}
+	return NewWithAuthenticator(CredentialAuth{Credentials: StaticCredentials(apiKey)}, "")
+}
+
+// ListAccounts returns all accounts the api key has access to.
+func (c *Client) ListAccounts() (*AccountsResp, error) {
+	url := fmt.Sprintf("%saccounts", baseURL)
+	resp := new(AccountsResp)
+	httpResp, err := c.do(&Request{Operation: "ListAccounts", Method: http.MethodGet, URL: url, Body: nil}, resp)
+	if httpResp == nil {
+		return nil, err
+	}
+	resp.StatusCode = httpResp.StatusCode
+	return resp, err
+}
+
+// FetchAccount fetches an account.
+func (c *Client) FetchAccount(accountID string) (*AccountsResp, error) {
+	if accountID == "" {
+		return nil, ErrBadAccountID
+	}
+	url := fmt.Sprintf("%saccounts/%s", baseURL, accountID)
+	resp := new(AccountsResp)
+	httpResp, err := c.do(&Request{Operation: "FetchAccount", Method: http.MethodGet, URL: url, Body: nil}, resp)
+	if httpResp == nil {
+		return nil, err
+	}
+	resp.StatusCode = httpResp.StatusCode
+	return resp, err
+}
+
+// AccountRegistry holds account scoped Clients keyed by name, e.g. one per brand.
+// All Clients are derived from the same base Client with ForAccount.
+type AccountRegistry struct {
+	base    *Client
+	mu      sync.RWMutex
+	clients map[string]*Client
+}
+
+// NewAccountRegistry returns an empty registry deriving Clients from base.
+func NewAccountRegistry(base *Client) *AccountRegistry {
+	return &AccountRegistry{
+		base:    base,
+		clients: make(map[string]*Client),
+	}
+}
+
+// Register adds or replaces the account for name and returns its Client.
+func (r *AccountRegistry) Register(name, accountID string) (*Client, error) {
+	if name == "" {
+		return nil, ErrInvalidInput
+	}
+	c, err := r.base.ForAccount(accountID)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	r.clients[name] = c
+	r.mu.Unlock()
+	return c, nil
+}
+
+// Get returns the Client registered for name.
+func (r *AccountRegistry) Get(name string) (*Client, error) {
+	r.mu.RLock()
+	c, ok := r.clients[name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownAccount, name)
+	}
+	return c, nil
+}
+
+// Names returns the registered names, sorted.
+func (r *AccountRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.clients))
+	for name := range r.clients {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
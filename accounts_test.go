@@ -0,0 +1,93 @@
+package drip_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/dynamite-jobs/drip-go"
+)
+
+func TestNewWithoutAccount(t *testing.T) {
+	if _, err := drip.NewWithoutAccount(""); err != drip.ErrBadAPIKey {
+		t.Fatalf("Failed to get ErrBadAPIKey")
+	}
+	dripClient, err := drip.NewWithoutAccount("abc123")
+	if err != nil {
+		t.Fatalf("Failed because got error: %s", err)
+	}
+	if _, err := dripClient.FetchSubscriber(testEmail); err != drip.ErrBadAccountID {
+		t.Fatalf("failed to get ErrBadAccountID for account scoped call: %v", err)
+	}
+	if _, err := dripClient.RecordEvent(testEmail, "Signed up", nil); err != drip.ErrBadAccountID {
+		t.Fatalf("failed to get ErrBadAccountID for account scoped call: %v", err)
+	}
+	dripClient, err = drip.NewWithCredentials(drip.StaticCredentials("abc123"), "")
+	if err != nil {
+		t.Fatalf("failed to get drip client without account: %s", err)
+	}
+	if _, err := dripClient.FetchSubscriber(testEmail); err != drip.ErrBadAccountID {
+		t.Fatalf("failed to get ErrBadAccountID for account scoped call: %v", err)
+	}
+}
+
+func TestListAccounts(t *testing.T) {
+	dripClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v2/accounts" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if user, _, ok := r.BasicAuth(); !ok || user != "testkey" {
+			t.Errorf("missing basic auth")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"accounts":[{"id":"9999999","name":"Acme, Inc."},{"id":"8888888","name":"Acme Labs"}]}`))
+	})
+	resp, err := dripClient.ListAccounts()
+	if err != nil {
+		t.Fatalf("failed to list accounts: %s", err)
+	}
+	if len(resp.Accounts) != 2 || resp.Accounts[1].ID != "8888888" {
+		t.Fatalf("unexpected accounts %+v", resp.Accounts)
+	}
+	if _, err := dripClient.FetchAccount(""); err != drip.ErrBadAccountID {
+		t.Fatalf("failed to get ErrBadAccountID: %v", err)
+	}
+}
+
+func TestAccountRegistry(t *testing.T) {
+	var paths []string
+	dripClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"workflows":[]}`))
+	})
+	registry := drip.NewAccountRegistry(dripClient)
+	if _, err := registry.Register("acme", "1111111"); err != nil {
+		t.Fatalf("failed to register: %s", err)
+	}
+	if _, err := registry.Register("labs", ""); err != drip.ErrBadAccountID {
+		t.Fatalf("failed to get ErrBadAccountID: %v", err)
+	}
+	acme, err := registry.Get("acme")
+	if err != nil {
+		t.Fatalf("failed to get acme: %s", err)
+	}
+	if acme.AccountID() != "1111111" || dripClient.AccountID() != "9999999" {
+		t.Fatalf("unexpected account ids %s %s", acme.AccountID(), dripClient.AccountID())
+	}
+	if acme.HTTPClient != dripClient.HTTPClient {
+		t.Fatalf("expected shared HTTPClient")
+	}
+	if _, err := acme.ListWorkflows(nil); err != nil {
+		t.Fatalf("failed to list workflows: %s", err)
+	}
+	if len(paths) != 1 || paths[0] != "/v2/1111111/workflows" {
+		t.Fatalf("unexpected paths %v", paths)
+	}
+	if _, err := registry.Get("labs"); !errors.Is(err, drip.ErrUnknownAccount) {
+		t.Fatalf("failed to get ErrUnknownAccount: %v", err)
+	}
+	if names := registry.Names(); len(names) != 1 || names[0] != "acme" {
+		t.Fatalf("unexpected names %v", names)
+	}
+}
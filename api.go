@@ -0,0 +1,101 @@
+package drip
+
+// SubscriberService creates, updates, fetches and deletes subscribers.
+type SubscriberService interface {
+	ListSubscribers(req *ListSubscribersReq) (*SubscribersResp, error)
+	UpdateSubscriber(req *UpdateSubscribersReq) (*SubscribersResp, error)
+	DeleteSubscriber(idOrEmail string) (*Response, error)
+	FetchSubscriber(idOrEmail string) (*SubscribersResp, error)
+	UpdateBatchSubscribers(req *UpdateBatchSubscribersReq) (*SubscribersResp, error)
+}
+
+// TagService adds and removes subscriber tags.
+type TagService interface {
+	TagSubscriber(req *TagsReq) (*Response, error)
+	RemoveSubscriberTag(req *TagReq) (*Response, error)
+}
+
+// EventService records custom events.
+type EventService interface {
+	RecordEvent(email, eventName string, properties map[string]interface{}) (*Response, error)
+}
+
+// ShopperActivityService records carts, orders and products with the v3 shopper activity API.
+type ShopperActivityService interface {
+	RecordCartActivity(req *Cart) (*ShopperActivityResp, error)
+	RecordOrderActivity(req *Order) (*ShopperActivityResp, error)
+	RecordOrderActivityBatch(req *OrderActivityBatchReq) (*ShopperActivityResp, error)
+	RecordProductActivity(req *Product) (*ShopperActivityResp, error)
+}
+
+// OrderService records orders, refunds and purchases with the v2 API.
+type OrderService interface {
+	CreateOrUpdateOrder(req *OrdersReq) (*Response, error)
+	CreateOrUpdateOrdersBatch(req *OrdersBatchReq) (*Response, error)
+	CreateRefund(req *RefundsReq) (*Response, error)
+	CreatePurchase(idOrEmail string, req *PurchasesReq) (*PurchasesResp, error)
+	ListPurchases(idOrEmail string) (*PurchasesResp, error)
+	FetchPurchase(idOrEmail, purchaseID string) (*PurchasesResp, error)
+}
+
+// WorkflowService manages workflows, their subscribers and triggers.
+type WorkflowService interface {
+	ListWorkflows(req *ListWorkflowsReq) (*WorkflowsResp, error)
+	FetchWorkflow(workflowID string) (*WorkflowsResp, error)
+	ActivateWorkflow(workflowID string) (*Response, error)
+	PauseWorkflow(workflowID string) (*Response, error)
+	StartWorkflow(workflowID string, req *UpdateSubscribersReq) (*SubscribersResp, error)
+	RemoveFromWorkflow(workflowID, idOrEmail string) (*Response, error)
+	ListWorkflowTriggers(workflowID string) (*WorkflowTriggersResp, error)
+	CreateWorkflowTrigger(workflowID string, req *WorkflowTriggersReq) (*WorkflowTriggersResp, error)
+	UpdateWorkflowTrigger(workflowID, triggerID string, req *WorkflowTriggersReq) (*WorkflowTriggersResp, error)
+}
+
+// BroadcastService reads broadcasts.
+type BroadcastService interface {
+	ListBroadcasts(req *ListBroadcastsReq) (*BroadcastsResp, error)
+	FetchBroadcast(broadcastID string) (*BroadcastsResp, error)
+}
+
+// FormService reads opt-in forms.
+type FormService interface {
+	ListForms() (*FormsResp, error)
+	FetchForm(formID string) (*FormsResp, error)
+	SubscriberForms(sub *Subscriber) ([]*Form, error)
+}
+
+// ConversionService reads conversions.
+type ConversionService interface {
+	ListConversions(req *ListConversionsReq) (*ConversionsResp, error)
+	FetchConversion(conversionID string) (*ConversionsResp, error)
+}
+
+// CustomFieldService reads custom field identifiers.
+type CustomFieldService interface {
+	ListCustomFieldIdentifiers() (*CustomFieldIdentifiersResp, error)
+}
+
+// AccountService reads the accounts and user of the api key.
+type AccountService interface {
+	ListAccounts() (*AccountsResp, error)
+	FetchAccount(accountID string) (*AccountsResp, error)
+	FetchUser() (*UsersResp, error)
+}
+
+// API is every Drip API call of Client. Depend on it, or one of the smaller services,
+// to swap in driptest.MockClient in tests.
+type API interface {
+	SubscriberService
+	TagService
+	EventService
+	ShopperActivityService
+	OrderService
+	WorkflowService
+	BroadcastService
+	FormService
+	ConversionService
+	CustomFieldService
+	AccountService
+}
+
+var _ API = (*Client)(nil)
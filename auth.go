@@ -0,0 +1,269 @@
+package drip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	oauthAuthURL  = "https://www.getdrip.com/oauth/authorize"
+	oauthTokenURL = "https://www.getdrip.com/oauth/token"
+)
+
+// Authenticator sets the credentials of a request to the Drip API.
+type Authenticator interface {
+	Authenticate(req *http.Request) error
+}
+
+// BasicAuth authenticates with an api key.
+type BasicAuth struct {
+	APIKey string
+}
+
+// Authenticate sets the api key as the basic auth username.
+func (a BasicAuth) Authenticate(req *http.Request) error {
+	if a.APIKey == "" {
+		return ErrBadAPIKey
+	}
+	req.SetBasicAuth(a.APIKey, "")
+	return nil
+}
+
+// String hides the api key so it is never logged.
+func (a BasicAuth) String() string {
+	return "BasicAuth(REDACTED)"
+}
+
+// GoString hides the api key so it is never logged.
+func (a BasicAuth) GoString() string {
+	return a.String()
+}
+
+// Token is an OAuth access token.
+type Token struct {
+	AccessToken  string    `json:"access_token"`
+	TokenType    string    `json:"token_type,omitempty"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	Scope        string    `json:"scope,omitempty"`
+	Expiry       time.Time `json:"expiry,omitempty"`
+}
+
+// Valid reports whether the token has an access token that has not expired.
+// Drip tokens have no expiry unless one is set.
+func (t *Token) Valid() bool {
+	return t != nil && t.AccessToken != "" && (t.Expiry.IsZero() || time.Now().Add(10*time.Second).Before(t.Expiry))
+}
+
+// TokenSource returns tokens for BearerAuth. It has the same shape as oauth2.TokenSource.
+type TokenSource interface {
+	Token() (*Token, error)
+}
+
+// ContextTokenSource is a TokenSource that can fetch tokens with a context.
+// BearerAuth uses the context of the request so a refresh stops when the call is canceled.
+type ContextTokenSource interface {
+	TokenSource
+	TokenContext(ctx context.Context) (*Token, error)
+}
+
+type staticTokenSource struct {
+	token *Token
+}
+
+func (s staticTokenSource) Token() (*Token, error) {
+	return s.token, nil
+}
+
+// StaticToken returns a TokenSource that always returns accessToken.
+func StaticToken(accessToken string) TokenSource {
+	return staticTokenSource{token: &Token{AccessToken: accessToken, TokenType: "Bearer"}}
+}
+
+// BearerAuth authenticates with an OAuth access token.
+type BearerAuth struct {
+	Source TokenSource
+}
+
+// Authenticate sets the Authorization header to the current token.
+func (a BearerAuth) Authenticate(req *http.Request) error {
+	if a.Source == nil {
+		return ErrInvalidInput
+	}
+	var t *Token
+	var err error
+	if s, ok := a.Source.(ContextTokenSource); ok {
+		t, err = s.TokenContext(req.Context())
+	} else {
+		t, err = a.Source.Token()
+	}
+	if err != nil {
+		return err
+	}
+	if t == nil || t.AccessToken == "" {
+		return ErrBadAPIKey
+	}
+	req.Header.Set("Authorization", "Bearer "+t.AccessToken)
+	return nil
+}
+
+// OAuthError is an error returned by the Drip OAuth token endpoint.
+type OAuthError struct {
+	StatusCode  int    `json:"-"`
+	Code        string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+// Error returns the error message.
+func (e *OAuthError) Error() string {
+	if e.Description != "" {
+		return fmt.Sprintf("oauth %s: %s", e.Code, e.Description)
+	}
+	return fmt.Sprintf("oauth StatusCode(%d) %s", e.StatusCode, e.Code)
+}
+
+// OAuthConfig is the configuration of a Drip OAuth application.
+// https://developer.drip.com/#oauth
+type OAuthConfig struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	// AuthURL and TokenURL default to the Drip endpoints.
+	AuthURL    string
+	TokenURL   string
+	HTTPClient *http.Client
+}
+
+// AuthCodeURL returns the url to send users to so they authorize the application.
+func (c *OAuthConfig) AuthCodeURL(state string) string {
+	authURL := c.AuthURL
+	if authURL == "" {
+		authURL = oauthAuthURL
+	}
+	v := url.Values{}
+	v.Set("response_type", "code")
+	v.Set("client_id", c.ClientID)
+	v.Set("redirect_uri", c.RedirectURL)
+	if state != "" {
+		v.Set("state", state)
+	}
+	if strings.Contains(authURL, "?") {
+		return authURL + "&" + v.Encode()
+	}
+	return authURL + "?" + v.Encode()
+}
+
+// Exchange trades the authorization code from the redirect for a Token.
+func (c *OAuthConfig) Exchange(ctx context.Context, code string) (*Token, error) {
+	if code == "" {
+		return nil, ErrInvalidInput
+	}
+	v := url.Values{}
+	v.Set("grant_type", "authorization_code")
+	v.Set("code", code)
+	v.Set("redirect_uri", c.RedirectURL)
+	return c.retrieveToken(ctx, v)
+}
+
+// Refresh trades a refresh token for a new Token.
+func (c *OAuthConfig) Refresh(ctx context.Context, refreshToken string) (*Token, error) {
+	if refreshToken == "" {
+		return nil, ErrInvalidInput
+	}
+	v := url.Values{}
+	v.Set("grant_type", "refresh_token")
+	v.Set("refresh_token", refreshToken)
+	return c.retrieveToken(ctx, v)
+}
+
+// TokenSource returns a TokenSource that returns t until it expires and then refreshes it.
+// It is a ContextTokenSource and is safe for concurrent use.
+func (c *OAuthConfig) TokenSource(t *Token) TokenSource {
+	return &refreshingTokenSource{config: c, token: t}
+}
+
+func (c *OAuthConfig) retrieveToken(ctx context.Context, v url.Values) (*Token, error) {
+	tokenURL := c.TokenURL
+	if tokenURL == "" {
+		tokenURL = oauthTokenURL
+	}
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	v.Set("client_id", c.ClientID)
+	v.Set("client_secret", c.ClientSecret)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(v.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		oauthErr := &OAuthError{StatusCode: resp.StatusCode}
+		if json.Unmarshal(b, oauthErr) != nil || oauthErr.Code == "" {
+			oauthErr.Code = strings.TrimSpace(string(b))
+		}
+		return nil, oauthErr
+	}
+	var tr struct {
+		Token
+		ExpiresIn int64 `json:"expires_in"`
+	}
+	if err := json.Unmarshal(b, &tr); err != nil {
+		return nil, err
+	}
+	if tr.AccessToken == "" {
+		return nil, &OAuthError{StatusCode: resp.StatusCode, Code: "server response missing access_token"}
+	}
+	t := tr.Token
+	if tr.ExpiresIn > 0 {
+		t.Expiry = time.Now().Add(time.Duration(tr.ExpiresIn) * time.Second)
+	}
+	return &t, nil
+}
+
+type refreshingTokenSource struct {
+	config *OAuthConfig
+	mu     sync.Mutex
+	token  *Token
+}
+
+func (s *refreshingTokenSource) Token() (*Token, error) {
+	return s.TokenContext(context.Background())
+}
+
+func (s *refreshingTokenSource) TokenContext(ctx context.Context) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token.Valid() {
+		return s.token, nil
+	}
+	if s.token == nil || s.token.RefreshToken == "" {
+		return nil, &OAuthError{Code: "token expired and refresh token is not set"}
+	}
+	t, err := s.config.Refresh(ctx, s.token.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+	if t.RefreshToken == "" {
+		t.RefreshToken = s.token.RefreshToken
+	}
+	s.token = t
+	return t, nil
+}
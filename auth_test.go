@@ -0,0 +1,120 @@
+package drip_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/dynamite-jobs/drip-go"
+)
+
+func newTokenServer(t *testing.T, refreshes *int) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("failed to parse form: %s", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.PostForm.Get("client_id") != "app" || r.PostForm.Get("client_secret") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"invalid_client","error_description":"Client authentication failed"}`))
+			return
+		}
+		switch r.PostForm.Get("grant_type") {
+		case "authorization_code":
+			if r.PostForm.Get("code") != "good-code" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":"invalid_grant","error_description":"The authorization code is invalid"}`))
+				return
+			}
+			w.Write([]byte(`{"access_token":"expired-token","token_type":"bearer","refresh_token":"refresh-1","expires_in":1}`))
+		case "refresh_token":
+			*refreshes++
+			w.Write([]byte(`{"access_token":"fresh-token","token_type":"bearer","expires_in":7200}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"unsupported_grant_type"}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestOAuthConfig(t *testing.T) {
+	var refreshes int
+	tokenSrv := newTokenServer(t, &refreshes)
+	config := &drip.OAuthConfig{
+		ClientID:     "app",
+		ClientSecret: "secret",
+		RedirectURL:  "https://example.com/callback",
+		TokenURL:     tokenSrv.URL,
+	}
+
+	authURL, err := url.Parse(config.AuthCodeURL("xyz"))
+	if err != nil {
+		t.Fatalf("failed to parse auth url: %s", err)
+	}
+	if authURL.Host != "www.getdrip.com" || authURL.Query().Get("state") != "xyz" || authURL.Query().Get("response_type") != "code" {
+		t.Fatalf("unexpected auth url %s", authURL)
+	}
+
+	var oauthErr *drip.OAuthError
+	if _, err := config.Exchange(context.Background(), "bad-code"); !errors.As(err, &oauthErr) || oauthErr.Code != "invalid_grant" {
+		t.Fatalf("failed to get invalid_grant: %v", err)
+	}
+	token, err := config.Exchange(context.Background(), "good-code")
+	if err != nil {
+		t.Fatalf("failed to exchange code: %s", err)
+	}
+	if token.AccessToken != "expired-token" || token.RefreshToken != "refresh-1" {
+		t.Fatalf("unexpected token %+v", token)
+	}
+
+	var gotAuth []string
+	dripClient, err := drip.NewWithAuthenticator(drip.BearerAuth{Source: config.TokenSource(token)}, "9999999")
+	if err != nil {
+		t.Fatalf("failed to get drip client: %s", err)
+	}
+	dripClient.HTTPClient = newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = append(gotAuth, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"accounts":[]}`))
+	}).HTTPClient
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := dripClient.WithContext(ctx).ListAccounts(); !errors.Is(err, context.Canceled) || refreshes != 0 {
+		t.Fatalf("failed to cancel refresh: %v refreshes %d", err, refreshes)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := dripClient.ListAccounts(); err != nil {
+			t.Fatalf("failed to list accounts: %s", err)
+		}
+	}
+	if refreshes != 1 {
+		t.Fatalf("expected one refresh, got %d", refreshes)
+	}
+	if len(gotAuth) != 2 || gotAuth[0] != "Bearer fresh-token" || gotAuth[1] != "Bearer fresh-token" {
+		t.Fatalf("unexpected authorization headers %v", gotAuth)
+	}
+
+	config.ClientSecret = "wrong"
+	if _, err := config.Exchange(context.Background(), "good-code"); err == nil || !strings.Contains(err.Error(), "invalid_client") {
+		t.Fatalf("failed to get invalid_client: %v", err)
+	}
+}
+
+func TestStaticToken(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "https://api.getdrip.com/v2/user", nil)
+	if err := (drip.BearerAuth{Source: drip.StaticToken("abc")}).Authenticate(req); err != nil {
+		t.Fatalf("failed to authenticate: %s", err)
+	}
+	if req.Header.Get("Authorization") != "Bearer abc" {
+		t.Fatalf("unexpected authorization header %q", req.Header.Get("Authorization"))
+	}
+	if _, err := drip.NewWithAuthenticator(nil, "9999999"); err != drip.ErrInvalidInput {
+		t.Fatalf("failed to get ErrInvalidInput: %v", err)
+	}
+}
@@ -0,0 +1,230 @@
+package drip
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without calling Drip while the circuit breaker of an account is open.
+var ErrCircuitOpen = fmt.Errorf("drip circuit breaker is open")
+
+// BreakerState is the state of a circuit breaker.
+type BreakerState int
+
+const (
+	// BreakerClosed lets all calls through.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen fails all calls with ErrCircuitOpen.
+	BreakerOpen
+	// BreakerHalfOpen lets probe calls through to check if Drip recovered.
+	BreakerHalfOpen
+)
+
+// String returns the name of the state.
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("BreakerState(%d)", int(s))
+}
+
+// BreakerOptions configures a CircuitBreaker. Zero values use the defaults.
+type BreakerOptions struct {
+	// FailureRatio of calls in a Window that trips the breaker. Default 0.5.
+	FailureRatio float64
+	// MinRequests in a Window before FailureRatio is checked. Default 10.
+	MinRequests int
+	// Window over which calls are counted. Default 1 minute.
+	Window time.Duration
+	// CoolDown is how long the breaker stays open before letting probes through. Default 30 seconds.
+	CoolDown time.Duration
+	// HalfOpenProbes is the number of successful probes needed to close the breaker. Default 1.
+	HalfOpenProbes int
+	// OnStateChange is called when the breaker of an account changes state.
+	OnStateChange func(accountID string, from, to BreakerState)
+}
+
+// CircuitBreaker stops calling Drip for an account after too many calls fail with a network error,
+// 429 or 5xx status. Each account has its own state, so it can be shared by Clients from ForAccount.
+type CircuitBreaker struct {
+	opts     BreakerOptions
+	mu       sync.Mutex
+	accounts map[string]*breakerAccount
+}
+
+type breakerAccount struct {
+	state       BreakerState
+	windowStart time.Time
+	requests    int
+	failures    int
+	openedAt    time.Time
+	probes      int
+	successes   int
+}
+
+// NewCircuitBreaker returns a CircuitBreaker. Add it to a Client with Use(b.Middleware()).
+func NewCircuitBreaker(opts BreakerOptions) *CircuitBreaker {
+	if opts.FailureRatio <= 0 {
+		opts.FailureRatio = 0.5
+	}
+	if opts.MinRequests <= 0 {
+		opts.MinRequests = 10
+	}
+	if opts.Window <= 0 {
+		opts.Window = time.Minute
+	}
+	if opts.CoolDown <= 0 {
+		opts.CoolDown = 30 * time.Second
+	}
+	if opts.HalfOpenProbes <= 0 {
+		opts.HalfOpenProbes = 1
+	}
+	return &CircuitBreaker{
+		opts:     opts,
+		accounts: make(map[string]*breakerAccount),
+	}
+}
+
+// State returns the state of the breaker for an account.
+func (b *CircuitBreaker) State(accountID string) BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	a, ok := b.accounts[accountID]
+	if !ok {
+		return BreakerClosed
+	}
+	if a.state == BreakerOpen && time.Since(a.openedAt) >= b.opts.CoolDown {
+		return BreakerHalfOpen
+	}
+	return a.state
+}
+
+// Middleware returns the Middleware applying the breaker. Add it before Retry so a call that
+// exhausts its retries counts as one failure.
+func (b *CircuitBreaker) Middleware() Middleware {
+	return func(next Handler) Handler {
+		return func(req *Request) (*http.Response, error) {
+			probe, err := b.allow(req.AccountID)
+			if err != nil {
+				return nil, err
+			}
+			resp, err := next(req)
+			failed := isFailure(req, resp, err)
+			if err != nil && !failed {
+				b.release(req.AccountID, probe)
+				return resp, err
+			}
+			b.record(req.AccountID, probe, failed)
+			return resp, err
+		}
+	}
+}
+
+// isFailure reports whether a call failed because of Drip: a network error, 429 or 5xx status.
+// Errors of the caller, like a canceled context or failing credentials, are not failures.
+func isFailure(req *Request, resp *http.Response, err error) bool {
+	if err != nil {
+		var urlErr *url.Error
+		return req.Context.Err() == nil && errors.As(err, &urlErr)
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+// allow reports whether a call may go to Drip and whether it is a half-open probe.
+func (b *CircuitBreaker) allow(accountID string) (bool, error) {
+	b.mu.Lock()
+	a, ok := b.accounts[accountID]
+	if !ok {
+		a = &breakerAccount{windowStart: time.Now()}
+		b.accounts[accountID] = a
+	}
+	from := a.state
+	probe := false
+	switch a.state {
+	case BreakerOpen:
+		if time.Since(a.openedAt) < b.opts.CoolDown {
+			b.mu.Unlock()
+			return false, ErrCircuitOpen
+		}
+		a.state = BreakerHalfOpen
+		a.probes, a.successes = 0, 0
+		fallthrough
+	case BreakerHalfOpen:
+		if a.probes >= b.opts.HalfOpenProbes {
+			to := a.state
+			b.mu.Unlock()
+			b.changed(accountID, from, to)
+			return false, ErrCircuitOpen
+		}
+		a.probes++
+		probe = true
+	}
+	to := a.state
+	b.mu.Unlock()
+	b.changed(accountID, from, to)
+	return probe, nil
+}
+
+// release frees the slot of a probe that ended before Drip answered.
+func (b *CircuitBreaker) release(accountID string, probe bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if a := b.accounts[accountID]; probe && a.state == BreakerHalfOpen && a.probes > 0 {
+		a.probes--
+	}
+}
+
+// record counts the result of a call. While half-open only probes count, so a call that started
+// before the breaker opened can not close it.
+func (b *CircuitBreaker) record(accountID string, probe, failed bool) {
+	b.mu.Lock()
+	a := b.accounts[accountID]
+	from := a.state
+	now := time.Now()
+	switch a.state {
+	case BreakerHalfOpen:
+		if !probe {
+			break
+		}
+		if failed {
+			a.state = BreakerOpen
+			a.openedAt = now
+			break
+		}
+		a.successes++
+		if a.successes >= b.opts.HalfOpenProbes {
+			a.state = BreakerClosed
+			a.windowStart, a.requests, a.failures = now, 0, 0
+		}
+	case BreakerClosed:
+		if now.Sub(a.windowStart) >= b.opts.Window {
+			a.windowStart, a.requests, a.failures = now, 0, 0
+		}
+		a.requests++
+		if failed {
+			a.failures++
+		}
+		if a.requests >= b.opts.MinRequests && float64(a.failures)/float64(a.requests) >= b.opts.FailureRatio {
+			a.state = BreakerOpen
+			a.openedAt = now
+		}
+	}
+	to := a.state
+	b.mu.Unlock()
+	b.changed(accountID, from, to)
+}
+
+func (b *CircuitBreaker) changed(accountID string, from, to BreakerState) {
+	if from != to && b.opts.OnStateChange != nil {
+		b.opts.OnStateChange(accountID, from, to)
+	}
+}
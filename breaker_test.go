@@ -0,0 +1,204 @@
+package drip_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dynamite-jobs/drip-go"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	var calls int
+	healthy := false
+	dripClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if !healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	var changes []string
+	breaker := drip.NewCircuitBreaker(drip.BreakerOptions{
+		FailureRatio: 0.5,
+		MinRequests:  4,
+		CoolDown:     20 * time.Millisecond,
+		OnStateChange: func(accountID string, from, to drip.BreakerState) {
+			changes = append(changes, accountID+" "+from.String()+"->"+to.String())
+		},
+	})
+	dripClient.Use(breaker.Middleware())
+
+	for i := 0; i < 4; i++ {
+		if _, err := dripClient.DeleteSubscriber(testEmail); err == drip.ErrCircuitOpen {
+			t.Fatalf("breaker opened after %d calls", i)
+		}
+	}
+	if breaker.State("9999999") != drip.BreakerOpen {
+		t.Fatalf("expected open breaker, got %s", breaker.State("9999999"))
+	}
+	if _, err := dripClient.DeleteSubscriber(testEmail); err != drip.ErrCircuitOpen {
+		t.Fatalf("failed to get ErrCircuitOpen: %v", err)
+	}
+	if calls != 4 {
+		t.Fatalf("expected 4 calls to reach drip, got %d", calls)
+	}
+
+	other, err := dripClient.ForAccount("1111111")
+	if err != nil {
+		t.Fatalf("failed to get account client: %s", err)
+	}
+	if _, err := other.DeleteSubscriber(testEmail); err == drip.ErrCircuitOpen {
+		t.Fatalf("breaker of another account is open")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if _, err := dripClient.DeleteSubscriber(testEmail); err == drip.ErrCircuitOpen {
+		t.Fatalf("failed to let probe through")
+	}
+	if breaker.State("9999999") != drip.BreakerOpen {
+		t.Fatalf("expected failed probe to reopen breaker, got %s", breaker.State("9999999"))
+	}
+
+	healthy = true
+	time.Sleep(30 * time.Millisecond)
+	if _, err := dripClient.DeleteSubscriber(testEmail); err != nil {
+		t.Fatalf("failed probe: %s", err)
+	}
+	if breaker.State("9999999") != drip.BreakerClosed {
+		t.Fatalf("expected closed breaker, got %s", breaker.State("9999999"))
+	}
+
+	want := []string{
+		"9999999 closed->open",
+		"9999999 open->half-open",
+		"9999999 half-open->open",
+		"9999999 open->half-open",
+		"9999999 half-open->closed",
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("unexpected state changes %v", changes)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Fatalf("unexpected state changes %v", changes)
+		}
+	}
+}
+
+func TestCircuitBreakerCallerErrors(t *testing.T) {
+	dripClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	breaker := drip.NewCircuitBreaker(drip.BreakerOptions{MinRequests: 2})
+	dripClient.Use(breaker.Middleware())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for i := 0; i < 4; i++ {
+		if _, err := dripClient.WithContext(ctx).DeleteSubscriber(testEmail); err == nil || err == drip.ErrCircuitOpen {
+			t.Fatalf("unexpected error for canceled call %d: %v", i, err)
+		}
+	}
+	if breaker.State("9999999") != drip.BreakerClosed {
+		t.Fatalf("canceled calls tripped the breaker")
+	}
+}
+
+func TestCircuitBreakerStaleCalls(t *testing.T) {
+	staleStarted, releaseStale := make(chan struct{}, 1), make(chan struct{})
+	probeStarted, releaseProbe := make(chan struct{}, 1), make(chan struct{})
+	dripClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "stale"):
+			staleStarted <- struct{}{}
+			<-releaseStale
+			w.WriteHeader(http.StatusNoContent)
+		case strings.Contains(r.URL.Path, "probe"):
+			probeStarted <- struct{}{}
+			<-releaseProbe
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	})
+	breaker := drip.NewCircuitBreaker(drip.BreakerOptions{MinRequests: 2, CoolDown: 20 * time.Millisecond})
+	dripClient.Use(breaker.Middleware())
+
+	staleDone := make(chan error)
+	go func() {
+		_, err := dripClient.DeleteSubscriber("stale@test.com")
+		staleDone <- err
+	}()
+	<-staleStarted
+	for i := 0; i < 2; i++ {
+		dripClient.DeleteSubscriber(testEmail)
+	}
+	if breaker.State("9999999") != drip.BreakerOpen {
+		t.Fatalf("expected open breaker, got %s", breaker.State("9999999"))
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	probeDone := make(chan error)
+	go func() {
+		_, err := dripClient.DeleteSubscriber("probe@test.com")
+		probeDone <- err
+	}()
+	<-probeStarted
+	close(releaseStale)
+	if err := <-staleDone; err != nil {
+		t.Fatalf("failed stale call: %s", err)
+	}
+	if breaker.State("9999999") != drip.BreakerHalfOpen {
+		t.Fatalf("stale call changed half-open breaker to %s", breaker.State("9999999"))
+	}
+	close(releaseProbe)
+	<-probeDone
+	if breaker.State("9999999") != drip.BreakerOpen {
+		t.Fatalf("expected failed probe to reopen breaker, got %s", breaker.State("9999999"))
+	}
+}
+
+func TestCircuitBreakerConcurrent(t *testing.T) {
+	var calls int32
+	dripClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	var mu sync.Mutex
+	var changes int
+	breaker := drip.NewCircuitBreaker(drip.BreakerOptions{
+		FailureRatio: 0.5,
+		MinRequests:  4,
+		CoolDown:     time.Nanosecond,
+		OnStateChange: func(accountID string, from, to drip.BreakerState) {
+			mu.Lock()
+			changes++
+			mu.Unlock()
+		},
+	})
+	dripClient.Use(breaker.Middleware())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				dripClient.DeleteSubscriber(testEmail)
+			}
+		}()
+	}
+	wg.Wait()
+	if breaker.State("9999999") == drip.BreakerClosed || changes == 0 {
+		t.Fatalf("expected breaker to open, got %s after %d changes", breaker.State("9999999"), changes)
+	}
+	if n := atomic.LoadInt32(&calls); n >= 8*200 {
+		t.Fatalf("expected breaker to stop calls, got %d", n)
+	}
+}
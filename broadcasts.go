@@ -0,0 +1,75 @@
+package drip
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Broadcast is a single-email send.
+// Drip's broadcast resource has no recipient, open or click counts, so there are no count fields;
+// counts are only shown in the Drip dashboard.
+type Broadcast struct {
+	ID                  string     `json:"id,omitempty"`
+	HREF                string     `json:"href,omitempty"`
+	Name                string     `json:"name,omitempty"`
+	Status              string     `json:"status,omitempty"`
+	FromName            string     `json:"from_name,omitempty"`
+	FromEmail           string     `json:"from_email,omitempty"`
+	PostalAddress       string     `json:"postal_address,omitempty"`
+	LocalizeSendingTime bool       `json:"localize_sending_time,omitempty"`
+	SendAt              *time.Time `json:"send_at,omitempty"`
+	BCC                 string     `json:"bcc,omitempty"`
+	Subject             string     `json:"subject,omitempty"`
+	HTMLBody            string     `json:"html_body,omitempty"`
+	TextBody            string     `json:"text_body,omitempty"`
+	CreatedAt           time.Time  `json:"created_at,omitempty"`
+	Links               Links      `json:"links,omitempty"`
+}
+
+// BroadcastsResp is a response recieved with broadcasts in it.
+// List functions have Meta for pagination. StatusCode is included in resp.
+type BroadcastsResp struct {
+	StatusCode int          `json:"status_code,omitempty"`
+	Links      Links        `json:"links,omitempty"`
+	Meta       Meta         `json:"meta,omitempty"`
+	Broadcasts []*Broadcast `json:"broadcasts,omitempty"`
+	Errors     []CodeError  `json:"errors,omitempty"`
+}
+
+// ListBroadcastsReq is a request for ListBroadcasts.
+// Status can be all, draft, scheduled or sent. Sort can be created_at, send_at or name. Direction can be asc or desc.
+type ListBroadcastsReq struct {
+	Status    string `url:"status,omitempty"`
+	Sort      string `url:"sort,omitempty"`
+	Direction string `url:"direction,omitempty"`
+	Page      *int   `url:"page,omitempty"`
+	PerPage   *int   `url:"per_page,omitempty"`
+}
+
+// ListBroadcasts returns a list of broadcasts.
+func (c *Client) ListBroadcasts(req *ListBroadcastsReq) (*BroadcastsResp, error) {
+	url := fmt.Sprintf("%s%s/broadcasts", baseURL, c.accountID)
+	resp := new(BroadcastsResp)
+	httpResp, err := c.do(&Request{Operation: "ListBroadcasts", Method: http.MethodGet, URL: url, Body: req}, resp)
+	if httpResp == nil {
+		return nil, err
+	}
+	resp.StatusCode = httpResp.StatusCode
+	return resp, err
+}
+
+// FetchBroadcast fetches a broadcast.
+func (c *Client) FetchBroadcast(broadcastID string) (*BroadcastsResp, error) {
+	if broadcastID == "" {
+		return nil, ErrInvalidInput
+	}
+	url := fmt.Sprintf("%s%s/broadcasts/%s", baseURL, c.accountID, broadcastID)
+	resp := new(BroadcastsResp)
+	httpResp, err := c.do(&Request{Operation: "FetchBroadcast", Method: http.MethodGet, URL: url, Body: nil}, resp)
+	if httpResp == nil {
+		return nil, err
+	}
+	resp.StatusCode = httpResp.StatusCode
+	return resp, err
+}
@@ -0,0 +1,35 @@
+package drip_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/dynamite-jobs/drip-go"
+)
+
+func TestListBroadcasts(t *testing.T) {
+	dripClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v2/9999999/broadcasts" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("status") != "sent" || q.Get("sort") != "send_at" || q.Get("direction") != "desc" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"broadcasts":[{"id":"123","name":"Launch","status":"sent","subject":"We launched","send_at":"2020-05-01T12:00:00Z"}],"meta":{"page":1,"count":1,"total_pages":1,"total_count":1}}`))
+	})
+	resp, err := dripClient.ListBroadcasts(&drip.ListBroadcastsReq{Status: "sent", Sort: "send_at", Direction: "desc"})
+	if err != nil {
+		t.Fatalf("failed to list broadcasts: %s", err)
+	}
+	if len(resp.Broadcasts) != 1 || resp.Broadcasts[0].Subject != "We launched" {
+		t.Fatalf("unexpected broadcasts %+v", resp.Broadcasts)
+	}
+	if resp.Broadcasts[0].SendAt == nil || resp.Broadcasts[0].SendAt.Year() != 2020 {
+		t.Fatalf("unexpected send_at %v", resp.Broadcasts[0].SendAt)
+	}
+	if _, err := dripClient.FetchBroadcast(""); err != drip.ErrInvalidInput {
+		t.Fatalf("failed to get ErrInvalidInput: %v", err)
+	}
+}
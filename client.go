@@ -2,6 +2,7 @@ package drip
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -31,8 +32,12 @@ var (
 type Client struct {
 	HTTPClient *http.Client
 	UserAgent  string
-	apiKey     string
-	accountID  string
+	// CustomFields validates outgoing subscriber custom fields when set.
+	CustomFields *CustomFieldRegistry
+	auth         Authenticator
+	accountID    string
+	middleware   []Middleware
+	ctx          context.Context
 }
 
 // New returns a new Client.
@@ -46,12 +51,44 @@ func New(apiKey, accountID string) (*Client, error) {
 	return &Client{
 		HTTPClient: http.DefaultClient,
 		UserAgent:  "drip-go client",
-		apiKey:     apiKey,
+		auth:       CredentialAuth{Credentials: StaticCredentials(apiKey)},
 		accountID:  accountID,
 	}, nil
 }
 
-func (c *Client) getReq(method, url string, body interface{}) (*http.Request, error) {
+// NewWithAuthenticator returns a new Client using auth for credentials, e.g. BearerAuth for OAuth apps.
+// accountID may be empty to only use methods that are not account scoped, like ListAccounts;
+// account scoped methods then return ErrBadAccountID.
+func NewWithAuthenticator(auth Authenticator, accountID string) (*Client, error) {
+	if auth == nil {
+		return nil, ErrInvalidInput
+	}
+	return &Client{
+		HTTPClient: http.DefaultClient,
+		UserAgent:  "drip-go client",
+		auth:       auth,
+		accountID:  accountID,
+	}, nil
+}
+
+// ForAccount returns a Client scoped to another account.
+// It shares the HTTPClient and settings of c, except CustomFields which belongs to a single account.
+func (c *Client) ForAccount(accountID string) (*Client, error) {
+	if accountID == "" {
+		return nil, ErrBadAccountID
+	}
+	ac := *c
+	ac.accountID = accountID
+	ac.CustomFields = nil
+	return &ac, nil
+}
+
+// AccountID returns the account the Client is scoped to.
+func (c *Client) AccountID() string {
+	return c.accountID
+}
+
+func (c *Client) getReq(ctx context.Context, method, url string, body interface{}) (*http.Request, error) {
 	var b io.Reader
 	if method == http.MethodGet {
 		v, err := query.Values(body)
@@ -67,11 +104,13 @@ func (c *Client) getReq(method, url string, body interface{}) (*http.Request, er
 		}
 		b = jsonOut
 	}
-	req, err := http.NewRequest(method, url, b)
+	req, err := http.NewRequestWithContext(ctx, method, url, b)
 	if err != nil {
 		return nil, err
 	}
-	req.SetBasicAuth(c.apiKey, "")
+	if err := c.auth.Authenticate(req); err != nil {
+		return nil, err
+	}
 	req.Header.Add("User-Agent", c.UserAgent)
 	req.Header.Add("Content-Type", "application/vnd.api+json")
 	return req, nil
@@ -164,18 +203,13 @@ type ListSubscribersReq struct {
 
 // ListSubscribers returns a list of subscribers. Either an ID or Email can
 func (c *Client) ListSubscribers(req *ListSubscribersReq) (*SubscribersResp, error) {
-	url := fmt.Sprintf("%s/%s/subscribers", baseURL, c.accountID)
-	httpReq, err := c.getReq(http.MethodGet, url, req)
-	if err != nil {
-		return nil, err
-	}
-	httpResp, err := c.HTTPClient.Do(httpReq)
-	if err != nil {
+	url := fmt.Sprintf("%s%s/subscribers", baseURL, c.accountID)
+	resp := new(SubscribersResp)
+	httpResp, err := c.do(&Request{Operation: "ListSubscribers", Method: http.MethodGet, URL: url, Body: req}, resp)
+	if httpResp == nil {
 		return nil, err
 	}
-	resp := new(SubscribersResp)
 	resp.StatusCode = httpResp.StatusCode
-	err = c.decodeResp(httpResp, resp)
 	return resp, err
 }
 
@@ -210,18 +244,18 @@ type UpdateSubscribersReq struct {
 // UpdateSubscriber creates or updates a subscriber.
 // If you need to create or update a collection of subscribers at once, use our batch API instead.
 func (c *Client) UpdateSubscriber(req *UpdateSubscribersReq) (*SubscribersResp, error) {
-	url := fmt.Sprintf("%s/%s/subscribers", baseURL, c.accountID)
-	httpReq, err := c.getReq(http.MethodPost, url, req)
-	if err != nil {
-		return nil, err
+	if c.CustomFields != nil && req != nil {
+		if err := c.CustomFields.ValidateSubscribers(req.Subscribers); err != nil {
+			return nil, err
+		}
 	}
-	httpResp, err := c.HTTPClient.Do(httpReq)
-	if err != nil {
+	url := fmt.Sprintf("%s%s/subscribers", baseURL, c.accountID)
+	resp := new(SubscribersResp)
+	httpResp, err := c.do(&Request{Operation: "UpdateSubscriber", Method: http.MethodPost, URL: url, Body: req}, resp)
+	if httpResp == nil {
 		return nil, err
 	}
-	resp := new(SubscribersResp)
 	resp.StatusCode = httpResp.StatusCode
-	err = c.decodeResp(httpResp, resp)
 	return resp, err
 }
 
@@ -230,18 +264,13 @@ func (c *Client) DeleteSubscriber(idOrEmail string) (*Response, error) {
 	if idOrEmail == "" {
 		return nil, ErrIDorEmailEmpty
 	}
-	url := fmt.Sprintf("%s/%s/subscribers/%s", baseURL, c.accountID, idOrEmail)
-	httpReq, err := c.getReq(http.MethodDelete, url, nil)
-	if err != nil {
-		return nil, err
-	}
-	httpResp, err := c.HTTPClient.Do(httpReq)
-	if err != nil {
+	url := fmt.Sprintf("%s%s/subscribers/%s", baseURL, c.accountID, idOrEmail)
+	resp := new(Response)
+	httpResp, err := c.do(&Request{Operation: "DeleteSubscriber", Method: http.MethodDelete, URL: url, Body: nil}, resp)
+	if httpResp == nil {
 		return nil, err
 	}
-	resp := new(Response)
 	resp.StatusCode = httpResp.StatusCode
-	err = c.decodeResp(httpResp, resp)
 	return resp, err
 }
 
@@ -250,18 +279,13 @@ func (c *Client) FetchSubscriber(idOrEmail string) (*SubscribersResp, error) {
 	if idOrEmail == "" {
 		return nil, ErrIDorEmailEmpty
 	}
-	url := fmt.Sprintf("%s/%s/subscribers/%s", baseURL, c.accountID, idOrEmail)
-	httpReq, err := c.getReq(http.MethodGet, url, nil)
-	if err != nil {
-		return nil, err
-	}
-	httpResp, err := c.HTTPClient.Do(httpReq)
-	if err != nil {
+	url := fmt.Sprintf("%s%s/subscribers/%s", baseURL, c.accountID, idOrEmail)
+	resp := new(SubscribersResp)
+	httpResp, err := c.do(&Request{Operation: "FetchSubscriber", Method: http.MethodGet, URL: url, Body: nil}, resp)
+	if httpResp == nil {
 		return nil, err
 	}
-	resp := new(SubscribersResp)
 	resp.StatusCode = httpResp.StatusCode
-	err = c.decodeResp(httpResp, resp)
 	return resp, err
 }
 
@@ -278,35 +302,25 @@ type TagReq struct {
 
 // TagSubscriber adds a tag to a subscriber.
 func (c *Client) TagSubscriber(req *TagsReq) (*Response, error) {
-	url := fmt.Sprintf("%s/%s/tags", baseURL, c.accountID)
-	httpReq, err := c.getReq(http.MethodPost, url, req)
-	if err != nil {
-		return nil, err
-	}
-	httpResp, err := c.HTTPClient.Do(httpReq)
-	if err != nil {
+	url := fmt.Sprintf("%s%s/tags", baseURL, c.accountID)
+	resp := new(Response)
+	httpResp, err := c.do(&Request{Operation: "TagSubscriber", Method: http.MethodPost, URL: url, Body: req}, resp)
+	if httpResp == nil {
 		return nil, err
 	}
-	resp := new(Response)
 	resp.StatusCode = httpResp.StatusCode
-	err = c.decodeResp(httpResp, resp)
 	return resp, err
 }
 
 // RemoveSubscriberTag adds a tag to a subscriber.
 func (c *Client) RemoveSubscriberTag(req *TagReq) (*Response, error) {
-	url := fmt.Sprintf("%s/%s/subscribers/%s/tags/%s", baseURL, c.accountID, req.Email, req.Tag)
-	httpReq, err := c.getReq(http.MethodDelete, url, nil)
-	if err != nil {
-		return nil, err
-	}
-	httpResp, err := c.HTTPClient.Do(httpReq)
-	if err != nil {
+	url := fmt.Sprintf("%s%s/subscribers/%s/tags/%s", baseURL, c.accountID, req.Email, req.Tag)
+	resp := new(Response)
+	httpResp, err := c.do(&Request{Operation: "RemoveSubscriberTag", Method: http.MethodDelete, URL: url, Body: nil}, resp)
+	if httpResp == nil {
 		return nil, err
 	}
-	resp := new(Response)
 	resp.StatusCode = httpResp.StatusCode
-	err = c.decodeResp(httpResp, resp)
 	return resp, err
 }
 
@@ -328,17 +342,12 @@ func (c Client) RecordEvent(email, eventName string, properties map[string]inter
 		},
 	}
 	path := fmt.Sprintf("%s%s/events", baseURL, c.accountID)
-	httpReq, err := c.getReq(http.MethodPost, path, bodyData)
-	if err != nil {
-		return nil, err
-	}
-	httpResp, err := c.HTTPClient.Do(httpReq)
-	if err != nil {
+	resp := new(Response)
+	httpResp, err := c.do(&Request{Operation: "RecordEvent", Method: http.MethodPost, URL: path, Body: bodyData}, resp)
+	if httpResp == nil {
 		return nil, err
 	}
-	resp := new(Response)
 	resp.StatusCode = httpResp.StatusCode
-	err = c.decodeResp(httpResp, resp)
 	return resp, err
 }
 
@@ -356,17 +365,26 @@ type UpdateBatchSubscribersReq struct {
 // We recommend using this API endpoint when you need to create or update a collection of subscribers at once.
 // Note: Since our batch APIs process requests in the background, there may be a delay between the time you submit your request and the time your data appears in user interface.
 func (c *Client) UpdateBatchSubscribers(req *UpdateBatchSubscribersReq) (*SubscribersResp, error) {
-	url := fmt.Sprintf("%s/%s/subscribers/batches", baseURL, c.accountID)
-	httpReq, err := c.getReq(http.MethodPost, url, req)
-	if err != nil {
-		return nil, err
+	if req == nil {
+		return nil, ErrInvalidInput
 	}
-	httpResp, err := c.HTTPClient.Do(httpReq)
-	if err != nil {
-		return nil, err
+	if c.CustomFields != nil {
+		for _, batch := range req.Batches {
+			if err := c.CustomFields.ValidateSubscribers(batch.Subscribers); err != nil {
+				return nil, err
+			}
+		}
+	}
+	url := fmt.Sprintf("%s%s/subscribers/batches", baseURL, c.accountID)
+	batchSize := 0
+	for _, batch := range req.Batches {
+		batchSize += len(batch.Subscribers)
 	}
 	resp := new(SubscribersResp)
+	httpResp, err := c.do(&Request{Operation: "UpdateBatchSubscribers", Method: http.MethodPost, URL: url, Body: req, BatchSize: batchSize}, resp)
+	if httpResp == nil {
+		return nil, err
+	}
 	resp.StatusCode = httpResp.StatusCode
-	err = c.decodeResp(httpResp, resp)
 	return resp, err
 }
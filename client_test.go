@@ -1,18 +1,16 @@
 package drip_test
 
 import (
-	"os"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"github.com/dynamite-jobs/drip-go"
+	"github.com/dynamite-jobs/drip-go/driptest"
 )
 
-var (
-	// TODO: setup for test
-	apiKey    = os.Getenv("DRIP_API_KEY")
-	accountID = os.Getenv("DRIP_ACCOUNT_ID")
-	testEmail = "test@test.com"
-)
+var testEmail = "test@test.com"
 
 type mockSubscribersResp struct {
 	desc         string
@@ -28,7 +26,6 @@ type mockResp struct {
 }
 
 func TestNew(t *testing.T) {
-	t.Logf("APIKey(%s) AccountID(%s", apiKey, accountID)
 	var err error
 	_, err = drip.New("", "123")
 	if err != drip.ErrBadAPIKey {
@@ -38,7 +35,7 @@ func TestNew(t *testing.T) {
 	if err != drip.ErrBadAccountID {
 		t.Errorf("Failed to get ErrBadAccountID")
 	}
-	_, err = drip.New(apiKey, accountID)
+	_, err = drip.New(driptest.DefaultAPIKey, driptest.DefaultAccountID)
 	if err != nil {
 		t.Errorf("Failed because got error: %s", err)
 	}
@@ -60,10 +57,9 @@ func TestListSubscribers(t *testing.T) {
 		},
 	}
 
-	dripClient, err := drip.New(apiKey, accountID)
-	if err != nil {
-		t.Fatalf("Failed to get drip client: %s", err)
-	}
+	dripClient := newFakeClient(t)
+	createTestEmail(t, dripClient)
+
 	for _, table := range tables {
 		resp, err := dripClient.ListSubscribers(table.req)
 		if err != nil && table.resp.hasError != true {
@@ -101,10 +97,7 @@ func TestUpdateSubscriber(t *testing.T) {
 		},
 	}
 
-	dripClient, err := drip.New(apiKey, accountID)
-	if err != nil {
-		t.Fatalf("Failed to get drip client: %s", err)
-	}
+	dripClient := newFakeClient(t)
 	for _, table := range tables {
 		resp, err := dripClient.UpdateSubscriber(table.req)
 		if err != nil && table.resp.hasError != true {
@@ -119,6 +112,24 @@ func TestUpdateSubscriber(t *testing.T) {
 	}
 }
 
+func TestUpdateBatchSubscribers(t *testing.T) {
+	dripClient := newFakeClient(t)
+	resp, err := dripClient.UpdateBatchSubscribers(&drip.UpdateBatchSubscribersReq{
+		Batches: []drip.SubscribersBatch{
+			{Subscribers: []drip.UpdateSubscriber{{Email: testEmail}}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to update batch: %s", err)
+	}
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("unexpected status code %d", resp.StatusCode)
+	}
+	if _, err := dripClient.UpdateBatchSubscribers(nil); err != drip.ErrInvalidInput {
+		t.Fatalf("failed to get ErrInvalidInput: %v", err)
+	}
+}
+
 func TestDeleteSubscriber(t *testing.T) {
 	tables := []struct {
 		idOrEmail string
@@ -142,10 +153,9 @@ func TestDeleteSubscriber(t *testing.T) {
 		},
 	}
 
-	dripClient, err := drip.New(apiKey, accountID)
-	if err != nil {
-		t.Fatalf("Failed to get drip client: %s", err)
-	}
+	dripClient := newFakeClient(t)
+	createTestEmail(t, dripClient)
+
 	for _, table := range tables {
 		resp, err := dripClient.DeleteSubscriber(table.idOrEmail)
 		if err != nil && table.resp.hasError != true {
@@ -182,10 +192,7 @@ func TestFetchSubscriber(t *testing.T) {
 		},
 	}
 
-	dripClient, err := drip.New(apiKey, accountID)
-	if err != nil {
-		t.Fatalf("Failed to get drip client: %s", err)
-	}
+	dripClient := newFakeClient(t)
 	createTestEmail(t, dripClient)
 
 	for _, table := range tables {
@@ -224,10 +231,7 @@ func TestTagsReq(t *testing.T) {
 		},
 	}
 
-	dripClient, err := drip.New(apiKey, accountID)
-	if err != nil {
-		t.Fatalf("Failed to get drip client: %s", err)
-	}
+	dripClient := newFakeClient(t)
 	createTestEmail(t, dripClient)
 
 	for _, table := range tables {
@@ -259,10 +263,7 @@ func TestRemoveSubscriberTag(t *testing.T) {
 		},
 	}
 
-	dripClient, err := drip.New(apiKey, accountID)
-	if err != nil {
-		t.Fatalf("Failed to get drip client: %s", err)
-	}
+	dripClient := newFakeClient(t)
 	createTestEmail(t, dripClient)
 
 	for _, table := range tables {
@@ -294,3 +295,38 @@ func createTestEmail(t *testing.T, dripClient *drip.Client) error {
 	}
 	return nil
 }
+
+// newFakeClient returns a drip client for a fake Drip server.
+func newFakeClient(t *testing.T) *drip.Client {
+	srv := driptest.NewServer()
+	t.Cleanup(srv.Close)
+	return srv.DripClient()
+}
+
+// rewriteTransport sends every request to a local test server.
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (t *rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// newTestClient returns a drip client whose requests are served by handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *drip.Client {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	target, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse test server url: %s", err)
+	}
+	dripClient, err := drip.New("testkey", "9999999")
+	if err != nil {
+		t.Fatalf("Failed to get drip client: %s", err)
+	}
+	dripClient.HTTPClient = &http.Client{Transport: &rewriteTransport{target: target}}
+	return dripClient
+}
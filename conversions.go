@@ -0,0 +1,65 @@
+package drip
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Conversion is a conversion goal. DefaultValue is in cents.
+type Conversion struct {
+	ID             string    `json:"id,omitempty"`
+	HREF           string    `json:"href,omitempty"`
+	Status         string    `json:"status,omitempty"`
+	Name           string    `json:"name,omitempty"`
+	URL            string    `json:"url,omitempty"`
+	DefaultValue   int       `json:"default_value,omitempty"`
+	CountingMethod string    `json:"counting_method,omitempty"`
+	CreatedAt      time.Time `json:"created_at,omitempty"`
+	Links          Links     `json:"links,omitempty"`
+}
+
+// ConversionsResp is a response recieved with conversions in it.
+// List functions have Meta for pagination. StatusCode is included in resp.
+type ConversionsResp struct {
+	StatusCode  int           `json:"status_code,omitempty"`
+	Links       Links         `json:"links,omitempty"`
+	Meta        Meta          `json:"meta,omitempty"`
+	Conversions []*Conversion `json:"goals,omitempty"`
+	Errors      []CodeError   `json:"errors,omitempty"`
+}
+
+// ListConversionsReq is a request for ListConversions.
+// Status can be all, active or disabled.
+type ListConversionsReq struct {
+	Status  string `url:"status,omitempty"`
+	Page    *int   `url:"page,omitempty"`
+	PerPage *int   `url:"per_page,omitempty"`
+}
+
+// ListConversions returns a list of conversions.
+func (c *Client) ListConversions(req *ListConversionsReq) (*ConversionsResp, error) {
+	url := fmt.Sprintf("%s%s/goals", baseURL, c.accountID)
+	resp := new(ConversionsResp)
+	httpResp, err := c.do(&Request{Operation: "ListConversions", Method: http.MethodGet, URL: url, Body: req}, resp)
+	if httpResp == nil {
+		return nil, err
+	}
+	resp.StatusCode = httpResp.StatusCode
+	return resp, err
+}
+
+// FetchConversion fetches a conversion.
+func (c *Client) FetchConversion(conversionID string) (*ConversionsResp, error) {
+	if conversionID == "" {
+		return nil, ErrInvalidInput
+	}
+	url := fmt.Sprintf("%s%s/goals/%s", baseURL, c.accountID, conversionID)
+	resp := new(ConversionsResp)
+	httpResp, err := c.do(&Request{Operation: "FetchConversion", Method: http.MethodGet, URL: url, Body: nil}, resp)
+	if httpResp == nil {
+		return nil, err
+	}
+	resp.StatusCode = httpResp.StatusCode
+	return resp, err
+}
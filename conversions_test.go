@@ -0,0 +1,31 @@
+package drip_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/dynamite-jobs/drip-go"
+)
+
+func TestListConversions(t *testing.T) {
+	dripClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v2/9999999/goals" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.URL.Query().Get("status") != "active" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"goals":[{"id":"9999","name":"Signed up","status":"active","default_value":2000,"counting_method":"once"}]}`))
+	})
+	resp, err := dripClient.ListConversions(&drip.ListConversionsReq{Status: "active"})
+	if err != nil {
+		t.Fatalf("failed to list conversions: %s", err)
+	}
+	if len(resp.Conversions) != 1 || resp.Conversions[0].DefaultValue != 2000 {
+		t.Fatalf("unexpected conversions %+v", resp.Conversions)
+	}
+	if _, err := dripClient.FetchConversion(""); err != drip.ErrInvalidInput {
+		t.Fatalf("failed to get ErrInvalidInput: %v", err)
+	}
+}
@@ -0,0 +1,131 @@
+package drip
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CredentialProvider returns the api key to use. It is consulted on every request so keys can rotate
+// without rebuilding the Client. Implementations must not log the key.
+type CredentialProvider interface {
+	APIKey() (string, error)
+}
+
+type staticCredentials struct {
+	apiKey string
+}
+
+// StaticCredentials returns a CredentialProvider that always returns apiKey.
+func StaticCredentials(apiKey string) CredentialProvider {
+	return &staticCredentials{apiKey: apiKey}
+}
+
+func (s *staticCredentials) APIKey() (string, error) {
+	if s.apiKey == "" {
+		return "", ErrBadAPIKey
+	}
+	return s.apiKey, nil
+}
+
+func (s *staticCredentials) String() string {
+	return "StaticCredentials(REDACTED)"
+}
+
+func (s *staticCredentials) GoString() string {
+	return s.String()
+}
+
+type envCredentials struct {
+	name string
+}
+
+// EnvCredentials returns a CredentialProvider that reads the api key from the environment variable name
+// on every request. An empty name uses DRIP_API_KEY.
+func EnvCredentials(name string) CredentialProvider {
+	if name == "" {
+		name = "DRIP_API_KEY"
+	}
+	return &envCredentials{name: name}
+}
+
+func (e *envCredentials) APIKey() (string, error) {
+	apiKey := strings.TrimSpace(os.Getenv(e.name))
+	if apiKey == "" {
+		return "", ErrBadAPIKey
+	}
+	return apiKey, nil
+}
+
+func (e *envCredentials) String() string {
+	return "EnvCredentials(" + e.name + ")"
+}
+
+type fileCredentials struct {
+	path    string
+	mu      sync.Mutex
+	apiKey  string
+	modTime time.Time
+	size    int64
+}
+
+// FileCredentials returns a CredentialProvider that reads the api key from the file at path, e.g. a
+// mounted secret. The file is reread whenever its modification time or size changes.
+func FileCredentials(path string) CredentialProvider {
+	return &fileCredentials{path: path}
+}
+
+func (f *fileCredentials) APIKey() (string, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return "", err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.apiKey == "" || !info.ModTime().Equal(f.modTime) || info.Size() != f.size {
+		b, err := ioutil.ReadFile(f.path)
+		if err != nil {
+			return "", err
+		}
+		f.apiKey = strings.TrimSpace(string(b))
+		f.modTime = info.ModTime()
+		f.size = info.Size()
+	}
+	if f.apiKey == "" {
+		return "", ErrBadAPIKey
+	}
+	return f.apiKey, nil
+}
+
+func (f *fileCredentials) String() string {
+	return "FileCredentials(" + f.path + ")"
+}
+
+// CredentialAuth authenticates with the api key of a CredentialProvider.
+type CredentialAuth struct {
+	Credentials CredentialProvider
+}
+
+// Authenticate sets the current api key as the basic auth username.
+func (a CredentialAuth) Authenticate(req *http.Request) error {
+	if a.Credentials == nil {
+		return ErrBadAPIKey
+	}
+	apiKey, err := a.Credentials.APIKey()
+	if err != nil {
+		return err
+	}
+	return BasicAuth{APIKey: apiKey}.Authenticate(req)
+}
+
+// NewWithCredentials returns a new Client that asks credentials for the api key on every request.
+// Like NewWithAuthenticator, accountID may be empty to only use methods that are not account scoped.
+func NewWithCredentials(credentials CredentialProvider, accountID string) (*Client, error) {
+	if credentials == nil {
+		return nil, ErrBadAPIKey
+	}
+	return NewWithAuthenticator(CredentialAuth{Credentials: credentials}, accountID)
+}
@@ -0,0 +1,85 @@
+package drip_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dynamite-jobs/drip-go"
+)
+
+func TestFileCredentials(t *testing.T) {
+	dir, err := ioutil.TempDir("", "drip")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	keyFile := filepath.Join(dir, "api_key")
+	if err := ioutil.WriteFile(keyFile, []byte("key-one\n"), 0600); err != nil {
+		t.Fatalf("failed to write key: %s", err)
+	}
+
+	var gotKeys []string
+	dripClient, err := drip.NewWithCredentials(drip.FileCredentials(keyFile), "9999999")
+	if err != nil {
+		t.Fatalf("failed to get drip client: %s", err)
+	}
+	dripClient.HTTPClient = newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		user, _, _ := r.BasicAuth()
+		gotKeys = append(gotKeys, user)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"users":[]}`))
+	}).HTTPClient
+
+	if _, err := dripClient.FetchUser(); err != nil {
+		t.Fatalf("failed to fetch user: %s", err)
+	}
+	future := time.Now().Add(time.Minute)
+	if err := ioutil.WriteFile(keyFile, []byte("key-two"), 0600); err != nil {
+		t.Fatalf("failed to rotate key: %s", err)
+	}
+	os.Chtimes(keyFile, future, future)
+	if _, err := dripClient.FetchUser(); err != nil {
+		t.Fatalf("failed to fetch user: %s", err)
+	}
+	if len(gotKeys) != 2 || gotKeys[0] != "key-one" || gotKeys[1] != "key-two" {
+		t.Fatalf("unexpected keys %v", gotKeys)
+	}
+
+	os.Remove(keyFile)
+	if _, err := dripClient.FetchUser(); err == nil {
+		t.Fatalf("failed to error on missing key file")
+	}
+}
+
+func TestEnvCredentials(t *testing.T) {
+	os.Setenv("DRIP_TEST_ROTATING_KEY", "env-key")
+	defer os.Unsetenv("DRIP_TEST_ROTATING_KEY")
+	creds := drip.EnvCredentials("DRIP_TEST_ROTATING_KEY")
+	if key, err := creds.APIKey(); err != nil || key != "env-key" {
+		t.Fatalf("unexpected key %q: %v", key, err)
+	}
+	os.Setenv("DRIP_TEST_ROTATING_KEY", "")
+	if _, err := creds.APIKey(); err != drip.ErrBadAPIKey {
+		t.Fatalf("failed to get ErrBadAPIKey: %v", err)
+	}
+}
+
+func TestCredentialsNotPrinted(t *testing.T) {
+	dripClient, err := drip.New("super-secret-key", "9999999")
+	if err != nil {
+		t.Fatalf("failed to get drip client: %s", err)
+	}
+	for _, format := range []string{"%v", "%+v", "%#v"} {
+		for _, v := range []interface{}{dripClient, drip.StaticCredentials("super-secret-key"), drip.BasicAuth{APIKey: "super-secret-key"}} {
+			if out := fmt.Sprintf(format, v); strings.Contains(out, "super-secret-key") {
+				t.Fatalf("%s leaked api key: %s", format, out)
+			}
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package drip
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrUnknownCustomField is returned if a custom field is not defined on the account.
+	ErrUnknownCustomField = fmt.Errorf("unknown custom field")
+	// ErrMalformedCustomField is returned if a custom field identifier is not valid.
+	ErrMalformedCustomField = fmt.Errorf("malformed custom field identifier")
+)
+
+// customFieldIdentifier matches the identifiers Drip accepts: letters, numbers and underscores.
+var customFieldIdentifier = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// CustomFieldIdentifiersResp is a response recieved with custom field identifiers in it.
+type CustomFieldIdentifiersResp struct {
+	StatusCode  int         `json:"status_code,omitempty"`
+	Identifiers []string    `json:"custom_field_identifiers,omitempty"`
+	Errors      []CodeError `json:"errors,omitempty"`
+}
+
+// ListCustomFieldIdentifiers returns all custom field identifiers used on the account.
+func (c *Client) ListCustomFieldIdentifiers() (*CustomFieldIdentifiersResp, error) {
+	url := fmt.Sprintf("%s%s/custom_field_identifiers", baseURL, c.accountID)
+	resp := new(CustomFieldIdentifiersResp)
+	httpResp, err := c.do(&Request{Operation: "ListCustomFieldIdentifiers", Method: http.MethodGet, URL: url, Body: nil}, resp)
+	if httpResp == nil {
+		return nil, err
+	}
+	resp.StatusCode = httpResp.StatusCode
+	return resp, err
+}
+
+// CustomFieldRegistry caches the custom field identifiers of an account and validates
+// outgoing CustomFields against them. Set it on Client.CustomFields to validate
+// UpdateSubscriber, UpdateBatchSubscribers and StartWorkflow requests before they are sent.
+type CustomFieldRegistry struct {
+	client    *Client
+	ttl       time.Duration
+	mu        sync.RWMutex
+	fields    map[string]struct{}
+	fetchedAt time.Time
+}
+
+// NewCustomFieldRegistry returns a registry backed by the identifiers of the client's account.
+// The identifiers are fetched on first use and refetched once older than ttl. A ttl of 0 never expires.
+func NewCustomFieldRegistry(c *Client, ttl time.Duration) *CustomFieldRegistry {
+	return &CustomFieldRegistry{
+		client: c,
+		ttl:    ttl,
+	}
+}
+
+// Refresh refetches the custom field identifiers from Drip.
+func (r *CustomFieldRegistry) Refresh() error {
+	resp, err := r.client.ListCustomFieldIdentifiers()
+	if err != nil {
+		return err
+	}
+	if len(resp.Errors) > 0 {
+		return resp.Errors[0]
+	}
+	fields := make(map[string]struct{}, len(resp.Identifiers))
+	for _, id := range resp.Identifiers {
+		fields[id] = struct{}{}
+	}
+	r.mu.Lock()
+	r.fields = fields
+	r.fetchedAt = time.Now()
+	r.mu.Unlock()
+	return nil
+}
+
+// Fields returns the known custom field identifiers, sorted.
+func (r *CustomFieldRegistry) Fields() ([]string, error) {
+	fields, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(fields))
+	for id := range fields {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// Validate returns an error if any key of fields is malformed or not defined on the account.
+// Malformed keys are rejected without contacting Drip.
+func (r *CustomFieldRegistry) Validate(fields map[string]string) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		if !customFieldIdentifier.MatchString(key) {
+			return fmt.Errorf("%w: %q", ErrMalformedCustomField, key)
+		}
+		keys = append(keys, key)
+	}
+	known, err := r.load()
+	if err != nil {
+		return err
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if _, ok := known[key]; !ok {
+			return fmt.Errorf("%w: %q", ErrUnknownCustomField, key)
+		}
+	}
+	return nil
+}
+
+// ValidateSubscribers validates the CustomFields of every subscriber.
+func (r *CustomFieldRegistry) ValidateSubscribers(subs []UpdateSubscriber) error {
+	for _, sub := range subs {
+		if err := r.Validate(sub.CustomFields); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *CustomFieldRegistry) load() (map[string]struct{}, error) {
+	r.mu.RLock()
+	fields, fetchedAt := r.fields, r.fetchedAt
+	r.mu.RUnlock()
+	if fields != nil && (r.ttl == 0 || time.Since(fetchedAt) < r.ttl) {
+		return fields, nil
+	}
+	if err := r.Refresh(); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.fields, nil
+}
@@ -0,0 +1,165 @@
+package drip
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// MarshalCustomFields converts a struct into CustomFields using the `drip:"field_name"` struct tag.
+// Fields without a drip tag or tagged `drip:"-"` are skipped, nil pointers are left out and
+// `drip:"field_name,omitempty"` leaves out zero values. Supported kinds are strings, ints, uints,
+// floats, bools, time.Time (RFC 3339 with fractional seconds) and pointers to them.
+func MarshalCustomFields(v interface{}) (map[string]string, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, ErrInvalidInput
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, ErrInvalidInput
+	}
+	fields := make(map[string]string)
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		name, omitEmpty, ok := customFieldTag(rt.Field(i))
+		if !ok {
+			continue
+		}
+		fv := rv.Field(i)
+		if fv.Kind() == reflect.Ptr {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+		}
+		if omitEmpty && fv.IsZero() {
+			continue
+		}
+		s, err := formatCustomField(fv)
+		if err != nil {
+			return nil, fmt.Errorf("drip: custom field %q: %w", name, err)
+		}
+		fields[name] = s
+	}
+	return fields, nil
+}
+
+// UnmarshalCustomFields fills the struct pointed to by v from CustomFields using the `drip:"field_name"`
+// struct tag. Missing or empty values leave the field untouched; pointers are allocated as needed.
+func UnmarshalCustomFields(fields map[string]string, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return ErrInvalidInput
+	}
+	rv = rv.Elem()
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		name, _, ok := customFieldTag(rt.Field(i))
+		if !ok {
+			continue
+		}
+		s, ok := fields[name]
+		if !ok || s == "" {
+			continue
+		}
+		fv := rv.Field(i)
+		if fv.Kind() == reflect.Ptr {
+			if fv.IsNil() {
+				fv.Set(reflect.New(fv.Type().Elem()))
+			}
+			fv = fv.Elem()
+		}
+		if err := parseCustomField(s, fv); err != nil {
+			return fmt.Errorf("drip: custom field %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func customFieldTag(f reflect.StructField) (name string, omitEmpty bool, ok bool) {
+	if f.PkgPath != "" {
+		return "", false, false
+	}
+	tag, ok := f.Tag.Lookup("drip")
+	if !ok || tag == "-" {
+		return "", false, false
+	}
+	parts := strings.Split(tag, ",")
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	if parts[0] == "" {
+		return "", false, false
+	}
+	return parts[0], omitEmpty, true
+}
+
+func formatCustomField(v reflect.Value) (string, error) {
+	if v.Type() == timeType {
+		return v.Interface().(time.Time).Format(time.RFC3339Nano), nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits()), nil
+	}
+	return "", fmt.Errorf("unsupported type %s", v.Type())
+}
+
+func parseCustomField(s string, v reflect.Value) error {
+	if v.Type() == timeType {
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
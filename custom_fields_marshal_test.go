@@ -0,0 +1,70 @@
+package drip_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dynamite-jobs/drip-go"
+)
+
+type profile struct {
+	Plan      string     `drip:"plan"`
+	Seats     int        `drip:"seats"`
+	MRR       float64    `drip:"mrr"`
+	Verified  bool       `drip:"verified"`
+	TrialEnds time.Time  `drip:"trial_ends_at"`
+	Referrer  *string    `drip:"referrer"`
+	ChurnedAt *time.Time `drip:"churned_at"`
+	Nickname  string     `drip:"nickname,omitempty"`
+	Internal  string     `drip:"-"`
+	Untagged  string
+}
+
+func TestMarshalCustomFields(t *testing.T) {
+	referrer := "newsletter"
+	in := profile{
+		Plan:      "pro",
+		Seats:     12,
+		MRR:       49.5,
+		Verified:  true,
+		TrialEnds: time.Date(2020, 6, 1, 0, 0, 0, 250000000, time.UTC),
+		Referrer:  &referrer,
+		Internal:  "secret",
+		Untagged:  "skip",
+	}
+	fields, err := drip.MarshalCustomFields(&in)
+	if err != nil {
+		t.Fatalf("failed to marshal: %s", err)
+	}
+	want := map[string]string{
+		"plan":          "pro",
+		"seats":         "12",
+		"mrr":           "49.5",
+		"verified":      "true",
+		"trial_ends_at": "2020-06-01T00:00:00.25Z",
+		"referrer":      "newsletter",
+	}
+	if !reflect.DeepEqual(fields, want) {
+		t.Fatalf("got %v want %v", fields, want)
+	}
+
+	var out profile
+	if err := drip.UnmarshalCustomFields(fields, &out); err != nil {
+		t.Fatalf("failed to unmarshal: %s", err)
+	}
+	in.Internal, in.Untagged = "", ""
+	if !reflect.DeepEqual(in, out) {
+		t.Fatalf("round trip got %+v want %+v", out, in)
+	}
+
+	if err := drip.UnmarshalCustomFields(map[string]string{"seats": "many"}, &out); err == nil {
+		t.Fatalf("failed to error on bad int")
+	}
+	if err := drip.UnmarshalCustomFields(fields, out); err != drip.ErrInvalidInput {
+		t.Fatalf("failed to get ErrInvalidInput for non pointer: %v", err)
+	}
+	if _, err := drip.MarshalCustomFields("pro"); err != drip.ErrInvalidInput {
+		t.Fatalf("failed to get ErrInvalidInput for non struct: %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+package drip_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/dynamite-jobs/drip-go"
+)
+
+func TestCustomFieldRegistry(t *testing.T) {
+	var fetches, updates int
+	dripClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v2/9999999/custom_field_identifiers":
+			fetches++
+			w.Write([]byte(`{"custom_field_identifiers":["first_name","plan"]}`))
+		case "/v2/9999999/subscribers":
+			updates++
+			w.Write([]byte(`{"subscribers":[{"email":"` + testEmail + `"}]}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+	registry := drip.NewCustomFieldRegistry(dripClient, 0)
+	dripClient.CustomFields = registry
+
+	tables := []struct {
+		fields map[string]string
+		err    error
+	}{
+		{fields: map[string]string{"first_name": "Jane", "plan": "pro"}, err: nil},
+		{fields: map[string]string{"favorite_color": "blue"}, err: drip.ErrUnknownCustomField},
+		{fields: map[string]string{"first name": "Jane"}, err: drip.ErrMalformedCustomField},
+		{fields: nil, err: nil},
+	}
+	for _, table := range tables {
+		_, err := dripClient.UpdateSubscriber(&drip.UpdateSubscribersReq{
+			Subscribers: []drip.UpdateSubscriber{
+				{Email: testEmail, CustomFields: table.fields},
+			},
+		})
+		if !errors.Is(err, table.err) {
+			t.Fatalf("fields %v got error %v want %v", table.fields, err, table.err)
+		}
+	}
+	if fetches != 1 {
+		t.Fatalf("expected identifiers to be fetched once, got %d", fetches)
+	}
+	if updates != 2 {
+		t.Fatalf("expected 2 requests to reach drip, got %d", updates)
+	}
+	if _, err := dripClient.UpdateSubscriber(nil); err != nil {
+		t.Fatalf("failed to send nil request: %s", err)
+	}
+
+	if err := registry.Refresh(); err != nil {
+		t.Fatalf("failed to refresh: %s", err)
+	}
+	fields, err := registry.Fields()
+	if err != nil {
+		t.Fatalf("failed to get fields: %s", err)
+	}
+	if fetches != 2 || len(fields) != 2 || fields[0] != "first_name" {
+		t.Fatalf("unexpected fields %v after %d fetches", fields, fetches)
+	}
+}
@@ -0,0 +1,62 @@
+// Package dripotel adapts OpenTelemetry tracers for drip.Tracing.
+package dripotel
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dynamite-jobs/drip-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/dynamite-jobs/drip-go"
+
+// NewTracer returns a drip.Tracer using tp. A nil tp uses the global TracerProvider.
+func NewTracer(tp trace.TracerProvider) drip.Tracer {
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	return &tracer{tracer: tp.Tracer(instrumentationName)}
+}
+
+type tracer struct {
+	tracer trace.Tracer
+}
+
+func (t *tracer) Start(ctx context.Context, spanName string) (context.Context, drip.Span) {
+	ctx, s := t.tracer.Start(ctx, spanName, trace.WithSpanKind(trace.SpanKindClient))
+	return ctx, &span{span: s}
+}
+
+type span struct {
+	span trace.Span
+}
+
+func (s *span) SetAttribute(key string, value interface{}) {
+	switch v := value.(type) {
+	case string:
+		s.span.SetAttributes(attribute.String(key, v))
+	case int:
+		s.span.SetAttributes(attribute.Int(key, v))
+	case int64:
+		s.span.SetAttributes(attribute.Int64(key, v))
+	case bool:
+		s.span.SetAttributes(attribute.Bool(key, v))
+	case []string:
+		s.span.SetAttributes(attribute.StringSlice(key, v))
+	default:
+		s.span.SetAttributes(attribute.String(key, fmt.Sprint(v)))
+	}
+}
+
+func (s *span) RecordError(err error) {
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+func (s *span) End() {
+	s.span.End()
+}
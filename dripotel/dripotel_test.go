@@ -0,0 +1,72 @@
+package dripotel_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/dynamite-jobs/drip-go"
+	"github.com/dynamite-jobs/drip-go/dripotel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (t *rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestNewTracer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errors":[{"code":"not_found_error","message":"The resource you requested was not found"}]}`))
+	}))
+	defer srv.Close()
+	target, _ := url.Parse(srv.URL)
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	dripClient, err := drip.New("testkey", "9999999")
+	if err != nil {
+		t.Fatalf("failed to get drip client: %s", err)
+	}
+	dripClient.HTTPClient = &http.Client{Transport: &rewriteTransport{target: target}}
+	dripClient.Use(drip.Tracing(dripotel.NewTracer(tp)))
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+	dripClient.WithContext(ctx).FetchWorkflow("444")
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "drip.FetchWorkflow" || span.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Fatalf("unexpected span %s with parent %s", span.Name(), span.Parent().SpanID())
+	}
+	if span.Status().Code != codes.Error {
+		t.Fatalf("expected error status, got %v", span.Status())
+	}
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	if attrs["http.response.status_code"].AsInt64() != 404 || attrs["drip.account_id"].AsString() != "9999999" {
+		t.Fatalf("unexpected attributes %v", span.Attributes())
+	}
+	if codes := attrs["drip.error_codes"].AsStringSlice(); len(codes) != 1 || codes[0] != "not_found_error" {
+		t.Fatalf("unexpected error codes %v", codes)
+	}
+}
@@ -0,0 +1,76 @@
+// Package dripprom adapts Prometheus for drip.Measure.
+package dripprom
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics is a drip.Metrics that records Prometheus metrics.
+type Metrics struct {
+	requests  *prometheus.CounterVec
+	latency   *prometheus.HistogramVec
+	retries   *prometheus.CounterVec
+	remaining *prometheus.GaugeVec
+	batchSize *prometheus.HistogramVec
+}
+
+// New returns Metrics registered with reg. A nil reg uses prometheus.DefaultRegisterer.
+func New(reg prometheus.Registerer) (*Metrics, error) {
+	if reg == nil {
+		reg = prometheus.DefaultRegisterer
+	}
+	m := &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "drip_requests_total",
+			Help: "Drip API calls by operation and HTTP status, status is 0 if no response was recieved.",
+		}, []string{"operation", "status"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "drip_request_duration_seconds",
+			Help:    "Latency of Drip API calls including retries.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"operation"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "drip_retries_total",
+			Help: "Retries of Drip API calls.",
+		}, []string{"operation"}),
+		remaining: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "drip_rate_limit_remaining",
+			Help: "Requests left in the Drip rate limit window.",
+		}, []string{"account_id"}),
+		batchSize: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "drip_batch_size",
+			Help:    "Records sent by Drip batch operations.",
+			Buckets: []float64{1, 10, 50, 100, 250, 500, 1000},
+		}, []string{"operation"}),
+	}
+	for _, c := range []prometheus.Collector{m.requests, m.latency, m.retries, m.remaining, m.batchSize} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// ObserveRequest records a finished API call.
+func (m *Metrics) ObserveRequest(operation string, statusCode int, latency time.Duration) {
+	m.requests.WithLabelValues(operation, strconv.Itoa(statusCode)).Inc()
+	m.latency.WithLabelValues(operation).Observe(latency.Seconds())
+}
+
+// ObserveRetries records the retries made by an API call.
+func (m *Metrics) ObserveRetries(operation string, retries int) {
+	m.retries.WithLabelValues(operation).Add(float64(retries))
+}
+
+// SetRateLimitRemaining records the requests left in the rate limit window of an account.
+func (m *Metrics) SetRateLimitRemaining(accountID string, remaining int) {
+	m.remaining.WithLabelValues(accountID).Set(float64(remaining))
+}
+
+// ObserveBatchSize records the number of records sent by a batch operation.
+func (m *Metrics) ObserveBatchSize(operation string, size int) {
+	m.batchSize.WithLabelValues(operation).Observe(float64(size))
+}
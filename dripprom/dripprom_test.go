@@ -0,0 +1,52 @@
+package dripprom_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dynamite-jobs/drip-go"
+	"github.com/dynamite-jobs/drip-go/dripprom"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+var _ drip.Metrics = (*dripprom.Metrics)(nil)
+
+func TestMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m, err := dripprom.New(reg)
+	if err != nil {
+		t.Fatalf("failed to create metrics: %s", err)
+	}
+	m.ObserveRequest("UpdateSubscriber", 200, 20*time.Millisecond)
+	m.ObserveRequest("UpdateSubscriber", 200, 30*time.Millisecond)
+	m.ObserveRequest("UpdateSubscriber", 0, time.Second)
+	m.ObserveRetries("UpdateSubscriber", 2)
+	m.SetRateLimitRemaining("9999999", 3500)
+	m.ObserveBatchSize("UpdateBatchSubscribers", 1000)
+
+	want := `
+# HELP drip_requests_total Drip API calls by operation and HTTP status, status is 0 if no response was recieved.
+# TYPE drip_requests_total counter
+drip_requests_total{operation="UpdateSubscriber",status="0"} 1
+drip_requests_total{operation="UpdateSubscriber",status="200"} 2
+# HELP drip_retries_total Retries of Drip API calls.
+# TYPE drip_retries_total counter
+drip_retries_total{operation="UpdateSubscriber"} 2
+# HELP drip_rate_limit_remaining Requests left in the Drip rate limit window.
+# TYPE drip_rate_limit_remaining gauge
+drip_rate_limit_remaining{account_id="9999999"} 3500
+`
+	err = testutil.GatherAndCompare(reg, strings.NewReader(want), "drip_requests_total", "drip_retries_total", "drip_rate_limit_remaining")
+	if err != nil {
+		t.Fatalf("unexpected metrics: %s", err)
+	}
+	if n := testutil.CollectAndCount(reg, "drip_batch_size", "drip_request_duration_seconds"); n != 2 {
+		t.Fatalf("expected 2 histograms, got %d", n)
+	}
+
+	if _, err := dripprom.New(reg); err == nil {
+		t.Fatalf("failed to error on duplicate registration")
+	}
+}
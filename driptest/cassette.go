@@ -0,0 +1,235 @@
+package driptest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// ErrNoInteraction is returned by a replaying Recorder for requests that are not in its cassette.
+var ErrNoInteraction = errors.New("driptest: no recorded interaction")
+
+// RecorderMode is the mode of a Recorder.
+type RecorderMode int
+
+const (
+	// ModeReplay answers requests from the cassette file without calling Drip.
+	ModeReplay RecorderMode = iota
+	// ModeRecord calls Drip and saves every request and response to the cassette file on Stop.
+	ModeRecord
+)
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a scrubbed request. Headers are not recorded.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// RecordedResponse is a scrubbed response.
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Cassette is the content of a cassette file.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder is an http.RoundTripper that records Drip API calls to a cassette file and replays them.
+// Set it as the Transport of Client.HTTPClient. Emails are replaced with placeholders derived from
+// their hash and API keys in Secrets with [scrubbed], in URLs, bodies and response headers; request
+// headers are never recorded. When replaying, placeholders in responses are replaced with the
+// emails of the request again.
+//
+// Requests are matched by method, URL and body, in the order they were recorded.
+type Recorder struct {
+	// Transport makes the real calls in ModeRecord. Defaults to http.DefaultTransport.
+	Transport http.RoundTripper
+	// Secrets are scrubbed from URLs, bodies and response headers, e.g. the API key.
+	Secrets []string
+
+	path      string
+	mode      RecorderMode
+	mu        sync.Mutex
+	cassette  Cassette
+	used      []bool
+	unmatched []string
+}
+
+// NewRecorder returns a Recorder for the cassette file at path. In ModeReplay the file must exist.
+func NewRecorder(path string, mode RecorderMode) (*Recorder, error) {
+	r := &Recorder{path: path, mode: mode}
+	if mode == ModeRecord {
+		return r, nil
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &r.cassette); err != nil {
+		return nil, fmt.Errorf("driptest: bad cassette %s: %w", path, err)
+	}
+	r.used = make([]bool, len(r.cassette.Interactions))
+	return r, nil
+}
+
+// HTTPClient returns an http.Client using the Recorder.
+func (r *Recorder) HTTPClient() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Stop saves the cassette in ModeRecord. In ModeReplay it returns an error listing the requests
+// that had no recorded interaction.
+func (r *Recorder) Stop() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.mode == ModeReplay {
+		if len(r.unmatched) > 0 {
+			return fmt.Errorf("%w for %s", ErrNoInteraction, strings.Join(r.unmatched, ", "))
+		}
+		return nil
+	}
+	b, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.path, append(b, '\n'), 0644)
+}
+
+// RoundTrip records or replays req.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	recorded := RecordedRequest{
+		Method: req.Method,
+		URL:    r.scrub(req.URL.String()),
+		Body:   r.scrub(string(body)),
+	}
+	if r.mode == ModeRecord {
+		return r.record(req, body, recorded)
+	}
+	return r.replay(req, body, recorded)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte, recorded RecordedRequest) (*http.Response, error) {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	req = req.Clone(req.Context())
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	header := make(http.Header)
+	for key, values := range resp.Header {
+		for _, value := range values {
+			header.Add(key, r.scrub(value))
+		}
+	}
+	header.Del("Set-Cookie")
+	header.Del("Date")
+	header.Del("Content-Length")
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request:  recorded,
+		Response: RecordedResponse{StatusCode: resp.StatusCode, Header: header, Body: r.scrub(string(respBody))},
+	})
+	r.mu.Unlock()
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request, body []byte, recorded RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, in := range r.cassette.Interactions {
+		if r.used[i] || in.Request != recorded {
+			continue
+		}
+		r.used[i] = true
+		respBody := in.Response.Body
+		header := in.Response.Header.Clone()
+		for _, email := range cassetteEmailPattern.FindAllString(req.URL.String()+" "+string(body), -1) {
+			email = strings.Replace(email, "%40", "@", 1)
+			respBody = strings.Replace(respBody, scrubEmail(email), email, -1)
+			for _, values := range header {
+				for j, value := range values {
+					values[j] = strings.Replace(value, scrubEmail(email), email, -1)
+				}
+			}
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+			StatusCode:    in.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(strings.NewReader(respBody)),
+			ContentLength: int64(len(respBody)),
+			Request:       req,
+		}, nil
+	}
+	call := recorded.Method + " " + recorded.URL
+	r.unmatched = append(r.unmatched, call)
+	return nil, fmt.Errorf("%w for %s", ErrNoInteraction, call)
+}
+
+var cassetteEmailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+(@|%40)[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+// scrubEmail returns the placeholder recorded for email.
+func scrubEmail(email string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(email)))
+	return "scrubbed-" + hex.EncodeToString(sum[:6]) + "@example.com"
+}
+
+func (r *Recorder) scrub(s string) string {
+	for _, secret := range r.Secrets {
+		if secret != "" {
+			s = strings.Replace(s, secret, "[scrubbed]", -1)
+		}
+	}
+	return cassetteEmailPattern.ReplaceAllStringFunc(s, func(email string) string {
+		if strings.HasPrefix(email, "scrubbed-") && strings.HasSuffix(email, "@example.com") {
+			return email
+		}
+		return scrubEmail(strings.Replace(email, "%40", "@", 1))
+	})
+}
@@ -0,0 +1,89 @@
+package driptest_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dynamite-jobs/drip-go"
+	"github.com/dynamite-jobs/drip-go/driptest"
+)
+
+func TestRecorder(t *testing.T) {
+	cassette := filepath.Join(t.TempDir(), "fixtures", "subscribers.json")
+	run := func(dripClient *drip.Client) *drip.SubscribersResp {
+		if _, err := dripClient.UpdateSubscriber(&drip.UpdateSubscribersReq{
+			Subscribers: []drip.UpdateSubscriber{{Email: "test@test.com", Tags: []string{"dev"}}},
+		}); err != nil {
+			t.Fatalf("failed to update subscriber: %s", err)
+		}
+		resp, err := dripClient.FetchSubscriber("test@test.com")
+		if err != nil {
+			t.Fatalf("failed to fetch subscriber: %s", err)
+		}
+		return resp
+	}
+
+	srv := driptest.NewServer()
+	rec, err := driptest.NewRecorder(cassette, driptest.ModeRecord)
+	if err != nil {
+		t.Fatalf("failed to create recorder: %s", err)
+	}
+	rec.Transport = locationTransport{next: srv.HTTPClient().Transport, location: "https://example.com/test@test.com?key=" + srv.APIKey}
+	rec.Secrets = []string{srv.APIKey}
+	dripClient := srv.DripClient()
+	dripClient.HTTPClient = rec.HTTPClient()
+	recorded := run(dripClient)
+	srv.Close()
+	if err := rec.Stop(); err != nil {
+		t.Fatalf("failed to save cassette: %s", err)
+	}
+	b, err := ioutil.ReadFile(cassette)
+	if err != nil {
+		t.Fatalf("failed to read cassette: %s", err)
+	}
+	if strings.Contains(string(b), "test@test.com") || strings.Contains(string(b), srv.APIKey) {
+		t.Fatalf("cassette not scrubbed: %s", b)
+	}
+	if strings.Contains(string(b), "Content-Length") {
+		t.Fatalf("cassette has Content-Length: %s", b)
+	}
+
+	rec, err = driptest.NewRecorder(cassette, driptest.ModeReplay)
+	if err != nil {
+		t.Fatalf("failed to load cassette: %s", err)
+	}
+	dripClient.HTTPClient = rec.HTTPClient()
+	replayed := run(dripClient)
+	if replayed.StatusCode != http.StatusOK || len(replayed.Subscribers) != 1 ||
+		replayed.Subscribers[0].Email != "test@test.com" || replayed.Subscribers[0].ID != recorded.Subscribers[0].ID {
+		t.Fatalf("unexpected replayed response %+v", replayed)
+	}
+	if err := rec.Stop(); err != nil {
+		t.Fatalf("failed to replay: %s", err)
+	}
+
+	if _, err := dripClient.DeleteSubscriber("test@test.com"); !errors.Is(err, driptest.ErrNoInteraction) {
+		t.Fatalf("failed to get ErrNoInteraction: %v", err)
+	}
+	if err := rec.Stop(); !errors.Is(err, driptest.ErrNoInteraction) {
+		t.Fatalf("failed to report unmatched request: %v", err)
+	}
+}
+
+// locationTransport adds a Location header to every response, like redirects from Drip.
+type locationTransport struct {
+	next     http.RoundTripper
+	location string
+}
+
+func (t locationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err == nil {
+		resp.Header.Set("Location", t.location)
+	}
+	return resp, err
+}
@@ -0,0 +1,176 @@
+package driptest
+
+import (
+	"math/rand"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/dynamite-jobs/drip-go"
+)
+
+// Fault is a failure injected by a Server into matching requests. Latency is added first, then the
+// first of Reset, HTML, Status and Truncate that is set decides the response. A Fault with only
+// Latency slows requests down and answers them normally.
+type Fault struct {
+	// Method matches the request method. Empty matches every method.
+	Method string
+	// Path matches the path after /v2/<account id> with path.Match, e.g. "/subscribers/*".
+	// Empty matches every path.
+	Path string
+	// Probability of injecting the fault into a matching request. 0 always injects it.
+	Probability float64
+	// Times is the number of requests the fault is injected into. 0 injects it into every request.
+	Times int
+
+	// Latency delays the response. The delay ends early if the client cancels the request.
+	Latency time.Duration
+	// Reset closes the connection without a response. net/http retries idempotent requests
+	// once when a reused connection is reset, so the client only sees resets on new connections
+	// or after the retry is reset too.
+	Reset bool
+	// HTML answers with an HTML error page and Status, or 502 if Status is 0.
+	HTML bool
+	// Status answers with a Drip error body, e.g. 429, 500 or 503.
+	Status int
+	// RetryAfter is sent as the Retry-After header with Status, e.g. "1".
+	RetryAfter string
+	// Truncate answers normally but cuts the JSON body in half.
+	Truncate bool
+}
+
+type faultState struct {
+	Fault
+	injected int
+}
+
+// Inject adds a fault. Faults are checked in the order they were added and the first match that
+// has not used up its Times is injected, so tests can script a sequence of failures.
+func (s *Server) Inject(faults ...Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, f := range faults {
+		s.faults = append(s.faults, &faultState{Fault: f})
+	}
+}
+
+// ClearFaults removes all injected faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	s.faults = nil
+	s.mu.Unlock()
+}
+
+// SetSeed seeds the source used for fault probabilities. NewServer seeds it with 1.
+func (s *Server) SetSeed(seed int64) {
+	s.mu.Lock()
+	s.rand = rand.New(rand.NewSource(seed))
+	s.mu.Unlock()
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f := s.fault(r)
+	if f == nil {
+		s.handle(w, r)
+		return
+	}
+	if f.Latency > 0 {
+		t := time.NewTimer(f.Latency)
+		select {
+		case <-t.C:
+		case <-r.Context().Done():
+			t.Stop()
+			return
+		}
+	}
+	switch {
+	case f.Reset:
+		resetConn(w)
+	case f.HTML:
+		status := f.Status
+		if status == 0 {
+			status = http.StatusBadGateway
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(status)
+		w.Write([]byte("<!DOCTYPE html>\n<html><head><title>" + http.StatusText(status) + "</title></head>" +
+			"<body><h1>" + http.StatusText(status) + "</h1></body></html>\n"))
+	case f.Status != 0:
+		if f.RetryAfter != "" {
+			w.Header().Set("Retry-After", f.RetryAfter)
+		}
+		writeError(w, statusError(f.Status))
+	case f.Truncate:
+		rec := httptest.NewRecorder()
+		s.handle(rec, r)
+		for key, values := range rec.Header() {
+			w.Header()[key] = values
+		}
+		body := rec.Body.Bytes()
+		w.WriteHeader(rec.Code)
+		w.Write(body[:len(body)/2])
+	default:
+		s.handle(w, r)
+	}
+}
+
+// fault returns the fault to inject into r, if any.
+func (s *Server) fault(r *http.Request) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rel := r.URL.Path
+	if prefix := "/v2/" + s.AccountID; strings.HasPrefix(rel, prefix) {
+		rel = strings.TrimPrefix(rel, prefix)
+	}
+	for _, f := range s.faults {
+		if f.Times > 0 && f.injected >= f.Times {
+			continue
+		}
+		if f.Method != "" && f.Method != r.Method {
+			continue
+		}
+		if f.Path != "" {
+			if ok, _ := path.Match(f.Path, rel); !ok {
+				continue
+			}
+		}
+		if f.Probability > 0 && s.rand.Float64() >= f.Probability {
+			continue
+		}
+		f.injected++
+		fault := f.Fault
+		return &fault
+	}
+	return nil
+}
+
+func statusError(status int) *apiError {
+	switch {
+	case status == http.StatusTooManyRequests:
+		return newError(status, "too_many_requests", "", "API rate limit exceeded. Please try again in an hour.")
+	case status == http.StatusServiceUnavailable:
+		return newError(status, string(drip.UnavailableError), "", "The service is temporarily unavailable")
+	case status >= 500:
+		return newError(status, "server_error", "", "Something went wrong. Please try again later.")
+	}
+	return newError(status, "fault_error", "", http.StatusText(status))
+}
+
+// resetConn closes the connection of w so the client sees a connection reset.
+func resetConn(w http.ResponseWriter) {
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		panic("driptest: connection can not be reset")
+	}
+	conn, _, err := hj.Hijack()
+	if err != nil {
+		panic(err)
+	}
+	if tcp, ok := conn.(*net.TCPConn); ok {
+		tcp.SetLinger(0)
+	}
+	conn.Close()
+}
@@ -0,0 +1,102 @@
+package driptest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/dynamite-jobs/drip-go"
+	"github.com/dynamite-jobs/drip-go/driptest"
+)
+
+func TestFaultsRetried(t *testing.T) {
+	srv := driptest.NewServer()
+	defer srv.Close()
+	srv.Inject(
+		driptest.Fault{Path: "/subscribers/*", Reset: true, Times: 1},
+		driptest.Fault{Path: "/subscribers/*", Status: http.StatusTooManyRequests, RetryAfter: "0", Times: 1},
+		driptest.Fault{Path: "/subscribers/*", Status: http.StatusServiceUnavailable, Times: 1},
+	)
+	var attempts int
+	dripClient := srv.DripClient()
+	dripClient.Use(
+		drip.Retry(3, time.Millisecond, time.Second),
+		func(next drip.Handler) drip.Handler {
+			return func(req *drip.Request) (*http.Response, error) {
+				attempts++
+				return next(req)
+			}
+		},
+	)
+	resp, err := dripClient.FetchSubscriber("test@test.com")
+	if err != nil {
+		t.Fatalf("failed to fetch subscriber: %s", err)
+	}
+	if resp.StatusCode != http.StatusNotFound || attempts != 4 {
+		t.Fatalf("unexpected status %d after %d attempts", resp.StatusCode, attempts)
+	}
+}
+
+func TestFaultsResponses(t *testing.T) {
+	srv := driptest.NewServer()
+	defer srv.Close()
+	dripClient := srv.DripClient()
+
+	srv.Inject(driptest.Fault{Method: http.MethodGet, Reset: true, Times: 1})
+	_, err := dripClient.ListSubscribers(&drip.ListSubscribersReq{})
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		t.Fatalf("failed to get url error on reset: %v", err)
+	}
+
+	srv.Inject(driptest.Fault{HTML: true, Times: 1})
+	if _, err := dripClient.ListSubscribers(&drip.ListSubscribersReq{}); err == nil {
+		t.Fatalf("failed to error on html page")
+	}
+
+	srv.Inject(driptest.Fault{Truncate: true, Times: 1})
+	if _, err := dripClient.ListSubscribers(&drip.ListSubscribersReq{}); err == nil {
+		t.Fatalf("failed to error on truncated json")
+	}
+
+	srv.Inject(driptest.Fault{Latency: time.Second, Times: 1})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := dripClient.WithContext(ctx).ListSubscribers(&drip.ListSubscribersReq{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("failed to time out on latency: %v", err)
+	}
+
+	resp, err := dripClient.ListSubscribers(&drip.ListSubscribersReq{})
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("failed to list subscribers after faults: %v %+v", err, resp)
+	}
+}
+
+func TestFaultsProbability(t *testing.T) {
+	srv := driptest.NewServer()
+	defer srv.Close()
+	srv.SetSeed(42)
+	srv.Inject(driptest.Fault{Status: http.StatusInternalServerError, Probability: 0.5})
+
+	breaker := drip.NewCircuitBreaker(drip.BreakerOptions{MinRequests: 20, FailureRatio: 0.3})
+	dripClient := srv.DripClient()
+	dripClient.Use(breaker.Middleware())
+	var failures, open int
+	for i := 0; i < 40; i++ {
+		resp, err := dripClient.ListSubscribers(&drip.ListSubscribersReq{})
+		switch {
+		case err == drip.ErrCircuitOpen:
+			open++
+		case err != nil:
+			t.Fatalf("failed to list subscribers: %s", err)
+		case resp.StatusCode == http.StatusInternalServerError:
+			failures++
+		}
+	}
+	if failures == 0 || open == 0 || breaker.State(srv.AccountID) != drip.BreakerOpen {
+		t.Fatalf("unexpected %d failures %d open calls state %s", failures, open, breaker.State(srv.AccountID))
+	}
+}
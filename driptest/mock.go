@@ -0,0 +1,421 @@
+// Package driptest provides test doubles for code using the drip package.
+package driptest
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/dynamite-jobs/drip-go"
+)
+
+// ErrNotMocked is returned by MockClient methods whose Func is not set.
+var ErrNotMocked = errors.New("driptest: method not mocked")
+
+// Call is a call recorded by MockClient.
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// MockClient is a drip.API whose methods call the matching Func field, e.g. UpdateSubscriber calls
+// UpdateSubscriberFunc. Methods whose Func is nil return ErrNotMocked. Every call is recorded.
+type MockClient struct {
+	ListSubscribersFunc            func(req *drip.ListSubscribersReq) (*drip.SubscribersResp, error)
+	UpdateSubscriberFunc           func(req *drip.UpdateSubscribersReq) (*drip.SubscribersResp, error)
+	DeleteSubscriberFunc           func(idOrEmail string) (*drip.Response, error)
+	FetchSubscriberFunc            func(idOrEmail string) (*drip.SubscribersResp, error)
+	UpdateBatchSubscribersFunc     func(req *drip.UpdateBatchSubscribersReq) (*drip.SubscribersResp, error)
+	TagSubscriberFunc              func(req *drip.TagsReq) (*drip.Response, error)
+	RemoveSubscriberTagFunc        func(req *drip.TagReq) (*drip.Response, error)
+	RecordEventFunc                func(email, eventName string, properties map[string]interface{}) (*drip.Response, error)
+	RecordCartActivityFunc         func(req *drip.Cart) (*drip.ShopperActivityResp, error)
+	RecordOrderActivityFunc        func(req *drip.Order) (*drip.ShopperActivityResp, error)
+	RecordOrderActivityBatchFunc   func(req *drip.OrderActivityBatchReq) (*drip.ShopperActivityResp, error)
+	RecordProductActivityFunc      func(req *drip.Product) (*drip.ShopperActivityResp, error)
+	CreateOrUpdateOrderFunc        func(req *drip.OrdersReq) (*drip.Response, error)
+	CreateOrUpdateOrdersBatchFunc  func(req *drip.OrdersBatchReq) (*drip.Response, error)
+	CreateRefundFunc               func(req *drip.RefundsReq) (*drip.Response, error)
+	CreatePurchaseFunc             func(idOrEmail string, req *drip.PurchasesReq) (*drip.PurchasesResp, error)
+	ListPurchasesFunc              func(idOrEmail string) (*drip.PurchasesResp, error)
+	FetchPurchaseFunc              func(idOrEmail, purchaseID string) (*drip.PurchasesResp, error)
+	ListWorkflowsFunc              func(req *drip.ListWorkflowsReq) (*drip.WorkflowsResp, error)
+	FetchWorkflowFunc              func(workflowID string) (*drip.WorkflowsResp, error)
+	ActivateWorkflowFunc           func(workflowID string) (*drip.Response, error)
+	PauseWorkflowFunc              func(workflowID string) (*drip.Response, error)
+	StartWorkflowFunc              func(workflowID string, req *drip.UpdateSubscribersReq) (*drip.SubscribersResp, error)
+	RemoveFromWorkflowFunc         func(workflowID, idOrEmail string) (*drip.Response, error)
+	ListWorkflowTriggersFunc       func(workflowID string) (*drip.WorkflowTriggersResp, error)
+	CreateWorkflowTriggerFunc      func(workflowID string, req *drip.WorkflowTriggersReq) (*drip.WorkflowTriggersResp, error)
+	UpdateWorkflowTriggerFunc      func(workflowID, triggerID string, req *drip.WorkflowTriggersReq) (*drip.WorkflowTriggersResp, error)
+	ListBroadcastsFunc             func(req *drip.ListBroadcastsReq) (*drip.BroadcastsResp, error)
+	FetchBroadcastFunc             func(broadcastID string) (*drip.BroadcastsResp, error)
+	ListFormsFunc                  func() (*drip.FormsResp, error)
+	FetchFormFunc                  func(formID string) (*drip.FormsResp, error)
+	SubscriberFormsFunc            func(sub *drip.Subscriber) ([]*drip.Form, error)
+	ListConversionsFunc            func(req *drip.ListConversionsReq) (*drip.ConversionsResp, error)
+	FetchConversionFunc            func(conversionID string) (*drip.ConversionsResp, error)
+	ListCustomFieldIdentifiersFunc func() (*drip.CustomFieldIdentifiersResp, error)
+	ListAccountsFunc               func() (*drip.AccountsResp, error)
+	FetchAccountFunc               func(accountID string) (*drip.AccountsResp, error)
+	FetchUserFunc                  func() (*drip.UsersResp, error)
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+var _ drip.API = (*MockClient)(nil)
+
+// Calls returns the calls made so far, in order.
+func (m *MockClient) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Call(nil), m.calls...)
+}
+
+func (m *MockClient) record(method string, args ...interface{}) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: method, Args: args})
+	m.mu.Unlock()
+}
+
+// ListSubscribers calls ListSubscribersFunc.
+func (m *MockClient) ListSubscribers(req *drip.ListSubscribersReq) (*drip.SubscribersResp, error) {
+	m.record("ListSubscribers", req)
+	if m.ListSubscribersFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.ListSubscribersFunc(req)
+}
+
+// UpdateSubscriber calls UpdateSubscriberFunc.
+func (m *MockClient) UpdateSubscriber(req *drip.UpdateSubscribersReq) (*drip.SubscribersResp, error) {
+	m.record("UpdateSubscriber", req)
+	if m.UpdateSubscriberFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.UpdateSubscriberFunc(req)
+}
+
+// DeleteSubscriber calls DeleteSubscriberFunc.
+func (m *MockClient) DeleteSubscriber(idOrEmail string) (*drip.Response, error) {
+	m.record("DeleteSubscriber", idOrEmail)
+	if m.DeleteSubscriberFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.DeleteSubscriberFunc(idOrEmail)
+}
+
+// FetchSubscriber calls FetchSubscriberFunc.
+func (m *MockClient) FetchSubscriber(idOrEmail string) (*drip.SubscribersResp, error) {
+	m.record("FetchSubscriber", idOrEmail)
+	if m.FetchSubscriberFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.FetchSubscriberFunc(idOrEmail)
+}
+
+// UpdateBatchSubscribers calls UpdateBatchSubscribersFunc.
+func (m *MockClient) UpdateBatchSubscribers(req *drip.UpdateBatchSubscribersReq) (*drip.SubscribersResp, error) {
+	m.record("UpdateBatchSubscribers", req)
+	if m.UpdateBatchSubscribersFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.UpdateBatchSubscribersFunc(req)
+}
+
+// TagSubscriber calls TagSubscriberFunc.
+func (m *MockClient) TagSubscriber(req *drip.TagsReq) (*drip.Response, error) {
+	m.record("TagSubscriber", req)
+	if m.TagSubscriberFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.TagSubscriberFunc(req)
+}
+
+// RemoveSubscriberTag calls RemoveSubscriberTagFunc.
+func (m *MockClient) RemoveSubscriberTag(req *drip.TagReq) (*drip.Response, error) {
+	m.record("RemoveSubscriberTag", req)
+	if m.RemoveSubscriberTagFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.RemoveSubscriberTagFunc(req)
+}
+
+// RecordEvent calls RecordEventFunc.
+func (m *MockClient) RecordEvent(email, eventName string, properties map[string]interface{}) (*drip.Response, error) {
+	m.record("RecordEvent", email, eventName, properties)
+	if m.RecordEventFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.RecordEventFunc(email, eventName, properties)
+}
+
+// RecordCartActivity calls RecordCartActivityFunc.
+func (m *MockClient) RecordCartActivity(req *drip.Cart) (*drip.ShopperActivityResp, error) {
+	m.record("RecordCartActivity", req)
+	if m.RecordCartActivityFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.RecordCartActivityFunc(req)
+}
+
+// RecordOrderActivity calls RecordOrderActivityFunc.
+func (m *MockClient) RecordOrderActivity(req *drip.Order) (*drip.ShopperActivityResp, error) {
+	m.record("RecordOrderActivity", req)
+	if m.RecordOrderActivityFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.RecordOrderActivityFunc(req)
+}
+
+// RecordOrderActivityBatch calls RecordOrderActivityBatchFunc.
+func (m *MockClient) RecordOrderActivityBatch(req *drip.OrderActivityBatchReq) (*drip.ShopperActivityResp, error) {
+	m.record("RecordOrderActivityBatch", req)
+	if m.RecordOrderActivityBatchFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.RecordOrderActivityBatchFunc(req)
+}
+
+// RecordProductActivity calls RecordProductActivityFunc.
+func (m *MockClient) RecordProductActivity(req *drip.Product) (*drip.ShopperActivityResp, error) {
+	m.record("RecordProductActivity", req)
+	if m.RecordProductActivityFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.RecordProductActivityFunc(req)
+}
+
+// CreateOrUpdateOrder calls CreateOrUpdateOrderFunc.
+func (m *MockClient) CreateOrUpdateOrder(req *drip.OrdersReq) (*drip.Response, error) {
+	m.record("CreateOrUpdateOrder", req)
+	if m.CreateOrUpdateOrderFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.CreateOrUpdateOrderFunc(req)
+}
+
+// CreateOrUpdateOrdersBatch calls CreateOrUpdateOrdersBatchFunc.
+func (m *MockClient) CreateOrUpdateOrdersBatch(req *drip.OrdersBatchReq) (*drip.Response, error) {
+	m.record("CreateOrUpdateOrdersBatch", req)
+	if m.CreateOrUpdateOrdersBatchFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.CreateOrUpdateOrdersBatchFunc(req)
+}
+
+// CreateRefund calls CreateRefundFunc.
+func (m *MockClient) CreateRefund(req *drip.RefundsReq) (*drip.Response, error) {
+	m.record("CreateRefund", req)
+	if m.CreateRefundFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.CreateRefundFunc(req)
+}
+
+// CreatePurchase calls CreatePurchaseFunc.
+func (m *MockClient) CreatePurchase(idOrEmail string, req *drip.PurchasesReq) (*drip.PurchasesResp, error) {
+	m.record("CreatePurchase", idOrEmail, req)
+	if m.CreatePurchaseFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.CreatePurchaseFunc(idOrEmail, req)
+}
+
+// ListPurchases calls ListPurchasesFunc.
+func (m *MockClient) ListPurchases(idOrEmail string) (*drip.PurchasesResp, error) {
+	m.record("ListPurchases", idOrEmail)
+	if m.ListPurchasesFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.ListPurchasesFunc(idOrEmail)
+}
+
+// FetchPurchase calls FetchPurchaseFunc.
+func (m *MockClient) FetchPurchase(idOrEmail, purchaseID string) (*drip.PurchasesResp, error) {
+	m.record("FetchPurchase", idOrEmail, purchaseID)
+	if m.FetchPurchaseFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.FetchPurchaseFunc(idOrEmail, purchaseID)
+}
+
+// ListWorkflows calls ListWorkflowsFunc.
+func (m *MockClient) ListWorkflows(req *drip.ListWorkflowsReq) (*drip.WorkflowsResp, error) {
+	m.record("ListWorkflows", req)
+	if m.ListWorkflowsFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.ListWorkflowsFunc(req)
+}
+
+// FetchWorkflow calls FetchWorkflowFunc.
+func (m *MockClient) FetchWorkflow(workflowID string) (*drip.WorkflowsResp, error) {
+	m.record("FetchWorkflow", workflowID)
+	if m.FetchWorkflowFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.FetchWorkflowFunc(workflowID)
+}
+
+// ActivateWorkflow calls ActivateWorkflowFunc.
+func (m *MockClient) ActivateWorkflow(workflowID string) (*drip.Response, error) {
+	m.record("ActivateWorkflow", workflowID)
+	if m.ActivateWorkflowFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.ActivateWorkflowFunc(workflowID)
+}
+
+// PauseWorkflow calls PauseWorkflowFunc.
+func (m *MockClient) PauseWorkflow(workflowID string) (*drip.Response, error) {
+	m.record("PauseWorkflow", workflowID)
+	if m.PauseWorkflowFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.PauseWorkflowFunc(workflowID)
+}
+
+// StartWorkflow calls StartWorkflowFunc.
+func (m *MockClient) StartWorkflow(workflowID string, req *drip.UpdateSubscribersReq) (*drip.SubscribersResp, error) {
+	m.record("StartWorkflow", workflowID, req)
+	if m.StartWorkflowFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.StartWorkflowFunc(workflowID, req)
+}
+
+// RemoveFromWorkflow calls RemoveFromWorkflowFunc.
+func (m *MockClient) RemoveFromWorkflow(workflowID, idOrEmail string) (*drip.Response, error) {
+	m.record("RemoveFromWorkflow", workflowID, idOrEmail)
+	if m.RemoveFromWorkflowFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.RemoveFromWorkflowFunc(workflowID, idOrEmail)
+}
+
+// ListWorkflowTriggers calls ListWorkflowTriggersFunc.
+func (m *MockClient) ListWorkflowTriggers(workflowID string) (*drip.WorkflowTriggersResp, error) {
+	m.record("ListWorkflowTriggers", workflowID)
+	if m.ListWorkflowTriggersFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.ListWorkflowTriggersFunc(workflowID)
+}
+
+// CreateWorkflowTrigger calls CreateWorkflowTriggerFunc.
+func (m *MockClient) CreateWorkflowTrigger(workflowID string, req *drip.WorkflowTriggersReq) (*drip.WorkflowTriggersResp, error) {
+	m.record("CreateWorkflowTrigger", workflowID, req)
+	if m.CreateWorkflowTriggerFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.CreateWorkflowTriggerFunc(workflowID, req)
+}
+
+// UpdateWorkflowTrigger calls UpdateWorkflowTriggerFunc.
+func (m *MockClient) UpdateWorkflowTrigger(workflowID, triggerID string, req *drip.WorkflowTriggersReq) (*drip.WorkflowTriggersResp, error) {
+	m.record("UpdateWorkflowTrigger", workflowID, triggerID, req)
+	if m.UpdateWorkflowTriggerFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.UpdateWorkflowTriggerFunc(workflowID, triggerID, req)
+}
+
+// ListBroadcasts calls ListBroadcastsFunc.
+func (m *MockClient) ListBroadcasts(req *drip.ListBroadcastsReq) (*drip.BroadcastsResp, error) {
+	m.record("ListBroadcasts", req)
+	if m.ListBroadcastsFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.ListBroadcastsFunc(req)
+}
+
+// FetchBroadcast calls FetchBroadcastFunc.
+func (m *MockClient) FetchBroadcast(broadcastID string) (*drip.BroadcastsResp, error) {
+	m.record("FetchBroadcast", broadcastID)
+	if m.FetchBroadcastFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.FetchBroadcastFunc(broadcastID)
+}
+
+// ListForms calls ListFormsFunc.
+func (m *MockClient) ListForms() (*drip.FormsResp, error) {
+	m.record("ListForms")
+	if m.ListFormsFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.ListFormsFunc()
+}
+
+// FetchForm calls FetchFormFunc.
+func (m *MockClient) FetchForm(formID string) (*drip.FormsResp, error) {
+	m.record("FetchForm", formID)
+	if m.FetchFormFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.FetchFormFunc(formID)
+}
+
+// SubscriberForms calls SubscriberFormsFunc.
+func (m *MockClient) SubscriberForms(sub *drip.Subscriber) ([]*drip.Form, error) {
+	m.record("SubscriberForms", sub)
+	if m.SubscriberFormsFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.SubscriberFormsFunc(sub)
+}
+
+// ListConversions calls ListConversionsFunc.
+func (m *MockClient) ListConversions(req *drip.ListConversionsReq) (*drip.ConversionsResp, error) {
+	m.record("ListConversions", req)
+	if m.ListConversionsFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.ListConversionsFunc(req)
+}
+
+// FetchConversion calls FetchConversionFunc.
+func (m *MockClient) FetchConversion(conversionID string) (*drip.ConversionsResp, error) {
+	m.record("FetchConversion", conversionID)
+	if m.FetchConversionFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.FetchConversionFunc(conversionID)
+}
+
+// ListCustomFieldIdentifiers calls ListCustomFieldIdentifiersFunc.
+func (m *MockClient) ListCustomFieldIdentifiers() (*drip.CustomFieldIdentifiersResp, error) {
+	m.record("ListCustomFieldIdentifiers")
+	if m.ListCustomFieldIdentifiersFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.ListCustomFieldIdentifiersFunc()
+}
+
+// ListAccounts calls ListAccountsFunc.
+func (m *MockClient) ListAccounts() (*drip.AccountsResp, error) {
+	m.record("ListAccounts")
+	if m.ListAccountsFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.ListAccountsFunc()
+}
+
+// FetchAccount calls FetchAccountFunc.
+func (m *MockClient) FetchAccount(accountID string) (*drip.AccountsResp, error) {
+	m.record("FetchAccount", accountID)
+	if m.FetchAccountFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.FetchAccountFunc(accountID)
+}
+
+// FetchUser calls FetchUserFunc.
+func (m *MockClient) FetchUser() (*drip.UsersResp, error) {
+	m.record("FetchUser")
+	if m.FetchUserFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.FetchUserFunc()
+}
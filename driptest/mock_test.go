@@ -0,0 +1,40 @@
+package driptest_test
+
+import (
+	"testing"
+
+	"github.com/dynamite-jobs/drip-go"
+	"github.com/dynamite-jobs/drip-go/driptest"
+)
+
+// signup is code under test that only depends on the services it uses.
+func signup(subs drip.SubscriberService, events drip.EventService, email string) error {
+	if _, err := subs.UpdateSubscriber(&drip.UpdateSubscribersReq{
+		Subscribers: []drip.UpdateSubscriber{{Email: email}},
+	}); err != nil {
+		return err
+	}
+	_, err := events.RecordEvent(email, "Signed up", nil)
+	return err
+}
+
+func TestMockClient(t *testing.T) {
+	mock := &driptest.MockClient{
+		UpdateSubscriberFunc: func(req *drip.UpdateSubscribersReq) (*drip.SubscribersResp, error) {
+			return &drip.SubscribersResp{StatusCode: 200}, nil
+		},
+	}
+	if err := signup(mock, mock, "test@test.com"); err != driptest.ErrNotMocked {
+		t.Fatalf("failed to get ErrNotMocked: %v", err)
+	}
+	mock.RecordEventFunc = func(email, eventName string, properties map[string]interface{}) (*drip.Response, error) {
+		return &drip.Response{StatusCode: 204}, nil
+	}
+	if err := signup(mock, mock, "test@test.com"); err != nil {
+		t.Fatalf("failed to sign up: %s", err)
+	}
+	calls := mock.Calls()
+	if len(calls) != 4 || calls[3].Method != "RecordEvent" || calls[3].Args[1] != "Signed up" {
+		t.Fatalf("unexpected calls %+v", calls)
+	}
+}
@@ -0,0 +1,689 @@
+package driptest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dynamite-jobs/drip-go"
+)
+
+const (
+	// DefaultAPIKey is the api key NewServer accepts.
+	DefaultAPIKey = "driptest-api-key"
+	// DefaultAccountID is the account NewServer serves.
+	DefaultAccountID = "9999999"
+	// DefaultRateLimit is the number of requests per hour NewServer allows. The count resets an hour
+	// after the first request of a window, like Drip's rate limit.
+	DefaultRateLimit = 3600
+)
+
+// Event is an event recorded by the Server.
+type Event struct {
+	Email      string                 `json:"email"`
+	Action     string                 `json:"action"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+	OccurredAt time.Time              `json:"occurred_at"`
+}
+
+// Campaign is an email series campaign served by the Server.
+type Campaign struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Status      string   `json:"status"`
+	Subscribers []string `json:"-"`
+}
+
+// Webhook is a webhook served by the Server.
+type Webhook struct {
+	ID                   string    `json:"id"`
+	HREF                 string    `json:"href,omitempty"`
+	PostURL              string    `json:"post_url"`
+	Version              string    `json:"version,omitempty"`
+	IncludeReceivedEmail bool      `json:"include_received_email"`
+	Events               []string  `json:"events,omitempty"`
+	CreatedAt            time.Time `json:"created_at"`
+}
+
+// Server is an in-memory fake of the Drip API for tests. It serves the subscriber, tag, event,
+// batch, campaign and webhook endpoints of one account, checks basic auth, paginates lists with
+// Meta, sends rate limit headers and answers with Drip style error bodies. Faults can be injected
+// with Inject.
+type Server struct {
+	*httptest.Server
+	APIKey    string
+	AccountID string
+
+	mu          sync.Mutex
+	faults      []*faultState
+	rand        *rand.Rand
+	rateLimit   int
+	requests    int
+	windowStart time.Time
+	nextID      int
+	subscribers []*drip.Subscriber
+	events      []Event
+	campaigns   []*Campaign
+	webhooks    []*Webhook
+}
+
+// NewServer starts a Server accepting DefaultAPIKey for DefaultAccountID. Close it when done.
+func NewServer() *Server {
+	s := &Server{
+		APIKey:    DefaultAPIKey,
+		AccountID: DefaultAccountID,
+		rateLimit: DefaultRateLimit,
+		rand:      rand.New(rand.NewSource(1)),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// HTTPClient returns an http.Client that sends requests for the Drip API to the Server.
+func (s *Server) HTTPClient() *http.Client {
+	target, _ := url.Parse(s.URL)
+	return &http.Client{Transport: &rewriteTransport{target: target}}
+}
+
+// DripClient returns a drip.Client for the Server's account.
+func (s *Server) DripClient() *drip.Client {
+	c, err := drip.New(s.APIKey, s.AccountID)
+	if err != nil {
+		panic(err)
+	}
+	c.HTTPClient = s.HTTPClient()
+	return c
+}
+
+// SetRateLimit sets the requests per hour reported in rate limit headers and starts a new window.
+// Requests over the limit get a 429 response.
+func (s *Server) SetRateLimit(limit int) {
+	s.mu.Lock()
+	s.rateLimit = limit
+	s.requests = 0
+	s.windowStart = time.Time{}
+	s.mu.Unlock()
+}
+
+// Subscriber returns a copy of the subscriber with the ID or email.
+func (s *Server) Subscriber(idOrEmail string) (drip.Subscriber, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sub := s.findSubscriber(idOrEmail)
+	if sub == nil {
+		return drip.Subscriber{}, false
+	}
+	cp := *sub
+	cp.Tags = append([]string(nil), sub.Tags...)
+	if sub.CustomFields != nil {
+		cp.CustomFields = make(map[string]string, len(sub.CustomFields))
+		for key, value := range sub.CustomFields {
+			cp.CustomFields[key] = value
+		}
+	}
+	cp.Links.Forms = append([]string(nil), sub.Links.Forms...)
+	return cp, true
+}
+
+// Events returns the events recorded so far.
+func (s *Server) Events() []Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Event(nil), s.events...)
+}
+
+// AddCampaign adds a campaign and returns its ID.
+func (s *Server) AddCampaign(name, status string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c := &Campaign{ID: s.newID(), Name: name, Status: status}
+	s.campaigns = append(s.campaigns, c)
+	return c.ID
+}
+
+// CampaignSubscribers returns the emails subscribed to a campaign.
+func (s *Server) CampaignSubscribers(campaignID string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, c := range s.campaigns {
+		if c.ID == campaignID {
+			return append([]string(nil), c.Subscribers...)
+		}
+	}
+	return nil
+}
+
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (t *rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// apiError is written as a Drip error response.
+type apiError struct {
+	status int
+	errors []drip.CodeError
+}
+
+func newError(status int, code, attribute, message string) *apiError {
+	return &apiError{status: status, errors: []drip.CodeError{{Code: code, Attribute: attribute, Message: message}}}
+}
+
+var errNotFound = newError(http.StatusNotFound, "not_found_error", "", "The resource you requested was not found")
+
+// handle answers a request from the in-memory state.
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.windowStart) >= time.Hour {
+		s.windowStart, s.requests = now, 0
+	}
+	s.requests++
+	remaining := s.rateLimit - s.requests
+	if remaining < 0 {
+		remaining = 0
+	}
+	w.Header().Set("X-RateLimit-Limit", strconv.Itoa(s.rateLimit))
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	if s.requests > s.rateLimit {
+		retryAfter := int(s.windowStart.Add(time.Hour).Sub(now).Seconds()) + 1
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+		writeError(w, newError(http.StatusTooManyRequests, "too_many_requests", "", "API rate limit exceeded. Please try again in an hour."))
+		return
+	}
+	if user, _, ok := r.BasicAuth(); !ok || user != s.APIKey {
+		writeError(w, newError(http.StatusUnauthorized, "authentication_error", "", "You are not authenticated"))
+		return
+	}
+
+	segs := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(segs) < 3 || segs[0] != "v2" {
+		writeError(w, errNotFound)
+		return
+	}
+	if segs[1] != s.AccountID {
+		writeError(w, newError(http.StatusUnauthorized, "authorization_error", "", "You are not authorized to access this resource"))
+		return
+	}
+	status, body, apiErr := s.route(r, segs[2:])
+	if apiErr != nil {
+		writeError(w, apiErr)
+		return
+	}
+	if body == nil {
+		w.WriteHeader(status)
+		return
+	}
+	writeJSON(w, status, body)
+}
+
+func (s *Server) route(r *http.Request, segs []string) (int, interface{}, *apiError) {
+	switch {
+	case segs[0] == "subscribers" && len(segs) == 1 && r.Method == http.MethodGet:
+		return s.listSubscribers(r)
+	case segs[0] == "subscribers" && len(segs) == 1 && r.Method == http.MethodPost:
+		return s.updateSubscribers(r)
+	case segs[0] == "subscribers" && len(segs) == 2 && segs[1] == "batches" && r.Method == http.MethodPost:
+		return s.updateSubscriberBatches(r)
+	case segs[0] == "subscribers" && len(segs) == 2 && r.Method == http.MethodGet:
+		return s.fetchSubscriber(segs[1])
+	case segs[0] == "subscribers" && len(segs) == 2 && r.Method == http.MethodDelete:
+		return s.deleteSubscriber(segs[1])
+	case segs[0] == "subscribers" && len(segs) == 4 && segs[2] == "tags" && r.Method == http.MethodDelete:
+		return s.removeTag(segs[1], segs[3])
+	case segs[0] == "tags" && len(segs) == 1 && r.Method == http.MethodPost:
+		return s.tagSubscribers(r)
+	case segs[0] == "events" && len(segs) == 1 && r.Method == http.MethodPost:
+		return s.recordEvents(r, http.StatusNoContent)
+	case segs[0] == "events" && len(segs) == 2 && segs[1] == "batches" && r.Method == http.MethodPost:
+		return s.recordEventBatches(r)
+	case segs[0] == "campaigns" && len(segs) == 1 && r.Method == http.MethodGet:
+		return s.listCampaigns(r)
+	case segs[0] == "campaigns" && len(segs) == 2 && r.Method == http.MethodGet:
+		return s.fetchCampaign(segs[1])
+	case segs[0] == "campaigns" && len(segs) == 3 && segs[2] == "subscribers" && r.Method == http.MethodPost:
+		return s.subscribeToCampaign(r, segs[1])
+	case segs[0] == "webhooks" && len(segs) == 1 && r.Method == http.MethodGet:
+		return http.StatusOK, map[string]interface{}{"webhooks": append([]*Webhook{}, s.webhooks...)}, nil
+	case segs[0] == "webhooks" && len(segs) == 1 && r.Method == http.MethodPost:
+		return s.createWebhook(r)
+	case segs[0] == "webhooks" && len(segs) == 2 && r.Method == http.MethodGet:
+		return s.fetchWebhook(segs[1])
+	case segs[0] == "webhooks" && len(segs) == 2 && r.Method == http.MethodDelete:
+		return s.deleteWebhook(segs[1])
+	}
+	return 0, nil, errNotFound
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, apiErr *apiError) {
+	writeJSON(w, apiErr.status, map[string]interface{}{"errors": apiErr.errors})
+}
+
+func decodeBody(r *http.Request, v interface{}) *apiError {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return newError(http.StatusBadRequest, "format_error", "", "Request body is not valid JSON")
+	}
+	return nil
+}
+
+func (s *Server) newID() string {
+	s.nextID++
+	return fmt.Sprintf("%020d", s.nextID)
+}
+
+func (s *Server) findSubscriber(idOrEmail string) *drip.Subscriber {
+	for _, sub := range s.subscribers {
+		if sub.ID == idOrEmail || strings.EqualFold(sub.Email, idOrEmail) {
+			return sub
+		}
+	}
+	return nil
+}
+
+func validEmail(email string) bool {
+	at := strings.Index(email, "@")
+	return at > 0 && strings.Contains(email[at:], ".")
+}
+
+// upsertSubscriber creates or updates a subscriber like POST /subscribers.
+func (s *Server) upsertSubscriber(u drip.UpdateSubscriber) (*drip.Subscriber, *apiError) {
+	sub := s.findSubscriber(u.ID)
+	if sub == nil && u.Email != "" {
+		sub = s.findSubscriber(u.Email)
+	}
+	if sub == nil {
+		if u.Email == "" {
+			return nil, newError(http.StatusUnprocessableEntity, string(drip.PresenceError), "email", "Email is required")
+		}
+		if !validEmail(u.Email) {
+			return nil, newError(http.StatusUnprocessableEntity, string(drip.EmailError), "email", "Email is not a valid email address")
+		}
+		id := s.newID()
+		sub = &drip.Subscriber{
+			ID:        id,
+			Status:    "active",
+			Email:     u.Email,
+			CreatedAt: time.Now().UTC(),
+			HREF:      fmt.Sprintf("https://api.getdrip.com/v2/%s/subscribers/%s", s.AccountID, id),
+			Links:     drip.Links{Account: s.AccountID},
+		}
+		s.subscribers = append(s.subscribers, sub)
+	}
+	if u.NewEmail != "" {
+		if !validEmail(u.NewEmail) {
+			return nil, newError(http.StatusUnprocessableEntity, string(drip.EmailError), "new_email", "New email is not a valid email address")
+		}
+		sub.Email = u.NewEmail
+	}
+	if u.UserID != "" {
+		sub.UserID = u.UserID
+	}
+	if u.TimeZone != "" {
+		sub.TimeZone = u.TimeZone
+	}
+	if u.IPAddress != "" {
+		sub.IPAddress = u.IPAddress
+	}
+	if u.Prospect != nil {
+		sub.Prospect = *u.Prospect
+	}
+	if u.BaseLeadScore != nil {
+		sub.BaseLeadScore = *u.BaseLeadScore
+	}
+	if u.LifetimeValue != nil {
+		sub.LifetimeValue = int(*u.LifetimeValue)
+	}
+	for key, value := range u.CustomFields {
+		if sub.CustomFields == nil {
+			sub.CustomFields = make(map[string]string)
+		}
+		sub.CustomFields[key] = value
+	}
+	for _, tag := range u.Tags {
+		addTag(sub, tag)
+	}
+	for _, tag := range u.RemoveTags {
+		removeTag(sub, tag)
+	}
+	return sub, nil
+}
+
+func addTag(sub *drip.Subscriber, tag string) {
+	for _, t := range sub.Tags {
+		if t == tag {
+			return
+		}
+	}
+	sub.Tags = append(sub.Tags, tag)
+}
+
+func removeTag(sub *drip.Subscriber, tag string) {
+	tags := sub.Tags[:0]
+	for _, t := range sub.Tags {
+		if t != tag {
+			tags = append(tags, t)
+		}
+	}
+	sub.Tags = tags
+}
+
+func hasAnyTag(sub *drip.Subscriber, tags []string) bool {
+	for _, want := range tags {
+		for _, tag := range sub.Tags {
+			if tag == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func subscribersBody(subs ...*drip.Subscriber) map[string]interface{} {
+	if subs == nil {
+		subs = []*drip.Subscriber{}
+	}
+	return map[string]interface{}{
+		"links":       map[string]string{"subscribers.account": "https://api.getdrip.com/v2/accounts/{subscribers.account}"},
+		"subscribers": subs,
+	}
+}
+
+// paginate returns the page of n items and its Meta. Drip defaults to 100 and allows up to 1000 per page.
+func paginate(q url.Values, n int) (start, end int, meta drip.Meta, apiErr *apiError) {
+	page, perPage := 1, 100
+	if v := q.Get("page"); v != "" {
+		p, err := strconv.Atoi(v)
+		if err != nil || p < 1 {
+			return 0, 0, meta, newError(http.StatusUnprocessableEntity, string(drip.RangeError), "page", "Page must be greater than 0")
+		}
+		page = p
+	}
+	if v := q.Get("per_page"); v != "" {
+		p, err := strconv.Atoi(v)
+		if err != nil || p < 1 || p > 1000 {
+			return 0, 0, meta, newError(http.StatusUnprocessableEntity, string(drip.RangeError), "per_page", "Per page must be between 1 and 1000")
+		}
+		perPage = p
+	}
+	start = (page - 1) * perPage
+	if start > n {
+		start = n
+	}
+	end = start + perPage
+	if end > n {
+		end = n
+	}
+	meta = drip.Meta{
+		Page:       page,
+		Count:      end - start,
+		TotalPages: (n + perPage - 1) / perPage,
+		TotalCount: n,
+	}
+	return start, end, meta, nil
+}
+
+func (s *Server) listSubscribers(r *http.Request) (int, interface{}, *apiError) {
+	q := r.URL.Query()
+	status := q.Get("status")
+	if status == "" {
+		status = "active"
+	}
+	var tags []string
+	for _, v := range q["tags"] {
+		tags = append(tags, strings.Split(v, ",")...)
+	}
+	var subs []*drip.Subscriber
+	for _, sub := range s.subscribers {
+		if status != "all" && sub.Status != status {
+			continue
+		}
+		if len(tags) > 0 && !hasAnyTag(sub, tags) {
+			continue
+		}
+		subs = append(subs, sub)
+	}
+	sort.SliceStable(subs, func(i, j int) bool { return subs[i].CreatedAt.After(subs[j].CreatedAt) })
+	start, end, meta, apiErr := paginate(q, len(subs))
+	if apiErr != nil {
+		return 0, nil, apiErr
+	}
+	body := subscribersBody(subs[start:end]...)
+	body["meta"] = meta
+	return http.StatusOK, body, nil
+}
+
+func (s *Server) updateSubscribers(r *http.Request) (int, interface{}, *apiError) {
+	var req drip.UpdateSubscribersReq
+	if apiErr := decodeBody(r, &req); apiErr != nil {
+		return 0, nil, apiErr
+	}
+	if len(req.Subscribers) == 0 {
+		return 0, nil, newError(http.StatusUnprocessableEntity, string(drip.PresenceError), "subscribers", "Subscribers is required")
+	}
+	sub, apiErr := s.upsertSubscriber(req.Subscribers[0])
+	if apiErr != nil {
+		return 0, nil, apiErr
+	}
+	return http.StatusOK, subscribersBody(sub), nil
+}
+
+func (s *Server) updateSubscriberBatches(r *http.Request) (int, interface{}, *apiError) {
+	var req drip.UpdateBatchSubscribersReq
+	if apiErr := decodeBody(r, &req); apiErr != nil {
+		return 0, nil, apiErr
+	}
+	for _, batch := range req.Batches {
+		if len(batch.Subscribers) > 1000 {
+			return 0, nil, newError(http.StatusUnprocessableEntity, string(drip.LengthError), "subscribers", "Batches may contain at most 1000 subscribers")
+		}
+		for _, u := range batch.Subscribers {
+			if _, apiErr := s.upsertSubscriber(u); apiErr != nil {
+				return 0, nil, apiErr
+			}
+		}
+	}
+	return http.StatusCreated, map[string]interface{}{}, nil
+}
+
+func (s *Server) fetchSubscriber(idOrEmail string) (int, interface{}, *apiError) {
+	sub := s.findSubscriber(idOrEmail)
+	if sub == nil {
+		return 0, nil, errNotFound
+	}
+	return http.StatusOK, subscribersBody(sub), nil
+}
+
+func (s *Server) deleteSubscriber(idOrEmail string) (int, interface{}, *apiError) {
+	for i, sub := range s.subscribers {
+		if sub.ID == idOrEmail || strings.EqualFold(sub.Email, idOrEmail) {
+			s.subscribers = append(s.subscribers[:i], s.subscribers[i+1:]...)
+			return http.StatusNoContent, nil, nil
+		}
+	}
+	return 0, nil, errNotFound
+}
+
+func (s *Server) tagSubscribers(r *http.Request) (int, interface{}, *apiError) {
+	var req drip.TagsReq
+	if apiErr := decodeBody(r, &req); apiErr != nil {
+		return 0, nil, apiErr
+	}
+	for _, t := range req.Tags {
+		if t.Tag == "" {
+			return 0, nil, newError(http.StatusUnprocessableEntity, string(drip.PresenceError), "tag", "Tag is required")
+		}
+		if _, apiErr := s.upsertSubscriber(drip.UpdateSubscriber{Email: t.Email, Tags: []string{t.Tag}}); apiErr != nil {
+			return 0, nil, apiErr
+		}
+	}
+	return http.StatusCreated, map[string]interface{}{}, nil
+}
+
+func (s *Server) removeTag(idOrEmail, tag string) (int, interface{}, *apiError) {
+	sub := s.findSubscriber(idOrEmail)
+	if sub == nil {
+		return 0, nil, errNotFound
+	}
+	removeTag(sub, tag)
+	return http.StatusNoContent, nil, nil
+}
+
+type eventsReq struct {
+	Events []Event `json:"events"`
+}
+
+func (s *Server) addEvents(events []Event) *apiError {
+	for _, e := range events {
+		if e.Action == "" {
+			return newError(http.StatusUnprocessableEntity, string(drip.PresenceError), "action", "Action is required")
+		}
+		if _, apiErr := s.upsertSubscriber(drip.UpdateSubscriber{Email: e.Email}); apiErr != nil {
+			return apiErr
+		}
+		if e.OccurredAt.IsZero() {
+			e.OccurredAt = time.Now().UTC()
+		}
+		s.events = append(s.events, e)
+	}
+	return nil
+}
+
+func (s *Server) recordEvents(r *http.Request, status int) (int, interface{}, *apiError) {
+	var req eventsReq
+	if apiErr := decodeBody(r, &req); apiErr != nil {
+		return 0, nil, apiErr
+	}
+	if apiErr := s.addEvents(req.Events); apiErr != nil {
+		return 0, nil, apiErr
+	}
+	return status, nil, nil
+}
+
+func (s *Server) recordEventBatches(r *http.Request) (int, interface{}, *apiError) {
+	var req struct {
+		Batches []eventsReq `json:"batches"`
+	}
+	if apiErr := decodeBody(r, &req); apiErr != nil {
+		return 0, nil, apiErr
+	}
+	for _, batch := range req.Batches {
+		if apiErr := s.addEvents(batch.Events); apiErr != nil {
+			return 0, nil, apiErr
+		}
+	}
+	return http.StatusCreated, map[string]interface{}{}, nil
+}
+
+func (s *Server) listCampaigns(r *http.Request) (int, interface{}, *apiError) {
+	q := r.URL.Query()
+	status := q.Get("status")
+	campaigns := []*Campaign{}
+	for _, c := range s.campaigns {
+		if status == "" || status == "all" || c.Status == status {
+			campaigns = append(campaigns, c)
+		}
+	}
+	start, end, meta, apiErr := paginate(q, len(campaigns))
+	if apiErr != nil {
+		return 0, nil, apiErr
+	}
+	return http.StatusOK, map[string]interface{}{"campaigns": campaigns[start:end], "meta": meta}, nil
+}
+
+func (s *Server) findCampaign(id string) *Campaign {
+	for _, c := range s.campaigns {
+		if c.ID == id {
+			return c
+		}
+	}
+	return nil
+}
+
+func (s *Server) fetchCampaign(id string) (int, interface{}, *apiError) {
+	c := s.findCampaign(id)
+	if c == nil {
+		return 0, nil, errNotFound
+	}
+	return http.StatusOK, map[string]interface{}{"campaigns": []*Campaign{c}}, nil
+}
+
+func (s *Server) subscribeToCampaign(r *http.Request, id string) (int, interface{}, *apiError) {
+	c := s.findCampaign(id)
+	if c == nil {
+		return 0, nil, errNotFound
+	}
+	var req drip.UpdateSubscribersReq
+	if apiErr := decodeBody(r, &req); apiErr != nil {
+		return 0, nil, apiErr
+	}
+	if len(req.Subscribers) == 0 {
+		return 0, nil, newError(http.StatusUnprocessableEntity, string(drip.PresenceError), "subscribers", "Subscribers is required")
+	}
+	sub, apiErr := s.upsertSubscriber(req.Subscribers[0])
+	if apiErr != nil {
+		return 0, nil, apiErr
+	}
+	c.Subscribers = append(c.Subscribers, sub.Email)
+	return http.StatusCreated, subscribersBody(sub), nil
+}
+
+func (s *Server) createWebhook(r *http.Request) (int, interface{}, *apiError) {
+	var req struct {
+		Webhooks []Webhook `json:"webhooks"`
+	}
+	if apiErr := decodeBody(r, &req); apiErr != nil {
+		return 0, nil, apiErr
+	}
+	if len(req.Webhooks) == 0 || req.Webhooks[0].PostURL == "" {
+		return 0, nil, newError(http.StatusUnprocessableEntity, string(drip.PresenceError), "post_url", "Post url is required")
+	}
+	if u, err := url.Parse(req.Webhooks[0].PostURL); err != nil || u.Host == "" {
+		return 0, nil, newError(http.StatusUnprocessableEntity, string(drip.URLError), "post_url", "Post url is not a valid URL")
+	}
+	wh := req.Webhooks[0]
+	wh.ID = s.newID()
+	wh.HREF = fmt.Sprintf("https://api.getdrip.com/v2/%s/webhooks/%s", s.AccountID, wh.ID)
+	wh.CreatedAt = time.Now().UTC()
+	s.webhooks = append(s.webhooks, &wh)
+	return http.StatusCreated, map[string]interface{}{"webhooks": []*Webhook{&wh}}, nil
+}
+
+func (s *Server) fetchWebhook(id string) (int, interface{}, *apiError) {
+	for _, wh := range s.webhooks {
+		if wh.ID == id {
+			return http.StatusOK, map[string]interface{}{"webhooks": []*Webhook{wh}}, nil
+		}
+	}
+	return 0, nil, errNotFound
+}
+
+func (s *Server) deleteWebhook(id string) (int, interface{}, *apiError) {
+	for i, wh := range s.webhooks {
+		if wh.ID == id {
+			s.webhooks = append(s.webhooks[:i], s.webhooks[i+1:]...)
+			return http.StatusNoContent, nil, nil
+		}
+	}
+	return 0, nil, errNotFound
+}
@@ -0,0 +1,153 @@
+package driptest_test
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dynamite-jobs/drip-go"
+	"github.com/dynamite-jobs/drip-go/driptest"
+)
+
+func TestServerSubscribers(t *testing.T) {
+	srv := driptest.NewServer()
+	defer srv.Close()
+	dripClient := srv.DripClient()
+
+	batch := drip.SubscribersBatch{}
+	for i := 0; i < 5; i++ {
+		batch.Subscribers = append(batch.Subscribers, drip.UpdateSubscriber{
+			Email: fmt.Sprintf("test%d@test.com", i),
+			Tags:  []string{"dev"},
+		})
+	}
+	resp, err := dripClient.UpdateBatchSubscribers(&drip.UpdateBatchSubscribersReq{Batches: []drip.SubscribersBatch{batch}})
+	if err != nil || resp.StatusCode != http.StatusCreated {
+		t.Fatalf("failed to update batch: %v %+v", err, resp)
+	}
+
+	page, perPage := 2, 2
+	resp, err = dripClient.ListSubscribers(&drip.ListSubscribersReq{Tags: []string{"dev"}, Page: &page, PerPage: &perPage})
+	if err != nil {
+		t.Fatalf("failed to list subscribers: %s", err)
+	}
+	if len(resp.Subscribers) != 2 || resp.Meta.Page != 2 || resp.Meta.TotalPages != 3 || resp.Meta.TotalCount != 5 {
+		t.Fatalf("unexpected page %d subscribers %+v", len(resp.Subscribers), resp.Meta)
+	}
+
+	if _, err := dripClient.TagSubscriber(&drip.TagsReq{Tags: []drip.TagReq{{Email: "test1@test.com", Tag: "vip"}}}); err != nil {
+		t.Fatalf("failed to tag subscriber: %s", err)
+	}
+	if _, err := dripClient.RemoveSubscriberTag(&drip.TagReq{Email: "test1@test.com", Tag: "dev"}); err != nil {
+		t.Fatalf("failed to remove tag: %s", err)
+	}
+	sub, ok := srv.Subscriber("test1@test.com")
+	if !ok || strings.Join(sub.Tags, ",") != "vip" {
+		t.Fatalf("unexpected subscriber %+v", sub)
+	}
+	sub.Tags[0] = "changed"
+	if sub, _ := srv.Subscriber("test1@test.com"); sub.Tags[0] != "vip" {
+		t.Fatalf("subscriber copy shares tags with the server")
+	}
+
+	if _, err := dripClient.RecordEvent("test1@test.com", "Signed up", map[string]interface{}{"plan": "pro"}); err != nil {
+		t.Fatalf("failed to record event: %s", err)
+	}
+	if events := srv.Events(); len(events) != 1 || events[0].Action != "Signed up" || events[0].Properties["plan"] != "pro" {
+		t.Fatalf("unexpected events %+v", events)
+	}
+
+	fetchResp, err := dripClient.FetchSubscriber("missing@test.com")
+	if err != nil {
+		t.Fatalf("failed to fetch subscriber: %s", err)
+	}
+	if fetchResp.StatusCode != http.StatusNotFound || len(fetchResp.Errors) != 1 || fetchResp.Errors[0].Code != "not_found_error" {
+		t.Fatalf("unexpected response %+v", fetchResp)
+	}
+
+	updateResp, err := dripClient.UpdateSubscriber(&drip.UpdateSubscribersReq{Subscribers: []drip.UpdateSubscriber{{Email: "not-an-email"}}})
+	if err != nil {
+		t.Fatalf("failed to update subscriber: %s", err)
+	}
+	if updateResp.StatusCode != http.StatusUnprocessableEntity || updateResp.Errors[0].Code != string(drip.EmailError) {
+		t.Fatalf("unexpected response %+v", updateResp)
+	}
+}
+
+func TestServerAuthAndRateLimit(t *testing.T) {
+	srv := driptest.NewServer()
+	defer srv.Close()
+
+	badClient, err := drip.New("wrong-key", srv.AccountID)
+	if err != nil {
+		t.Fatalf("failed to get drip client: %s", err)
+	}
+	badClient.HTTPClient = srv.HTTPClient()
+	resp, err := badClient.FetchSubscriber("test@test.com")
+	if err != nil {
+		t.Fatalf("failed to fetch subscriber: %s", err)
+	}
+	if resp.StatusCode != http.StatusUnauthorized || resp.Errors[0].Code != "authentication_error" {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+
+	srv.SetRateLimit(1)
+	var remaining []string
+	dripClient := srv.DripClient()
+	dripClient.Use(func(next drip.Handler) drip.Handler {
+		return func(req *drip.Request) (*http.Response, error) {
+			resp, err := next(req)
+			if err == nil {
+				remaining = append(remaining, resp.Header.Get("X-RateLimit-Remaining"))
+			}
+			return resp, err
+		}
+	})
+	dripClient.ListSubscribers(&drip.ListSubscribersReq{})
+	resp, err = dripClient.ListSubscribers(&drip.ListSubscribersReq{})
+	if err != nil {
+		t.Fatalf("failed to list subscribers: %s", err)
+	}
+	if resp.StatusCode != http.StatusTooManyRequests || strings.Join(remaining, ",") != "0,0" {
+		t.Fatalf("unexpected status %d remaining %v", resp.StatusCode, remaining)
+	}
+}
+
+func TestServerCampaignsAndWebhooks(t *testing.T) {
+	srv := driptest.NewServer()
+	defer srv.Close()
+	httpClient := srv.HTTPClient()
+	do := func(method, path, body string) *http.Response {
+		req, err := http.NewRequest(method, "https://api.getdrip.com/v2/"+srv.AccountID+path, strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("failed to create request: %s", err)
+		}
+		req.SetBasicAuth(srv.APIKey, "")
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			t.Fatalf("failed to %s %s: %s", method, path, err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+
+	id := srv.AddCampaign("Onboarding", "active")
+	if resp := do(http.MethodPost, "/campaigns/"+id+"/subscribers", `{"subscribers":[{"email":"test@test.com"}]}`); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("unexpected status %d", resp.StatusCode)
+	}
+	if subs := srv.CampaignSubscribers(id); len(subs) != 1 || subs[0] != "test@test.com" {
+		t.Fatalf("unexpected campaign subscribers %v", subs)
+	}
+	if resp := do(http.MethodGet, "/campaigns/missing", ""); resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("unexpected status %d", resp.StatusCode)
+	}
+
+	if resp := do(http.MethodPost, "/webhooks", `{"webhooks":[{"post_url":"not a url"}]}`); resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("unexpected status %d", resp.StatusCode)
+	}
+	if resp := do(http.MethodPost, "/webhooks", `{"webhooks":[{"post_url":"https://example.com/hook","events":["subscriber.created"]}]}`); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("unexpected status %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,89 @@
+package drip
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Form is an opt-in form.
+type Form struct {
+	ID                         string    `json:"id,omitempty"`
+	HREF                       string    `json:"href,omitempty"`
+	Headline                   string    `json:"headline,omitempty"`
+	Description                string    `json:"description,omitempty"`
+	ButtonText                 string    `json:"button_text,omitempty"`
+	ConfirmationHeading        string    `json:"confirmation_heading,omitempty"`
+	ConfirmationText           string    `json:"confirmation_text,omitempty"`
+	SendGAEvent                bool      `json:"send_ga_event,omitempty"`
+	SecondsBeforePopup         int       `json:"seconds_before_popup,omitempty"`
+	DaysBetweenPopup           int       `json:"days_between_popup,omitempty"`
+	DaysBetweenPopupAfterClose int       `json:"days_between_popup_after_close,omitempty"`
+	Orientation                string    `json:"orientation,omitempty"`
+	OptInType                  string    `json:"opt_in_type,omitempty"`
+	ShowLabels                 bool      `json:"show_labels,omitempty"`
+	Whitelist                  []string  `json:"whitelist,omitempty"`
+	Blacklist                  []string  `json:"blacklist,omitempty"`
+	IsWhitelistOn              bool      `json:"is_whitelist_on,omitempty"`
+	IsBlacklistOn              bool      `json:"is_blacklist_on,omitempty"`
+	HideOnMobile               bool      `json:"hide_on_mobile,omitempty"`
+	IsEmbeddable               bool      `json:"is_embeddable,omitempty"`
+	CreatedAt                  time.Time `json:"created_at,omitempty"`
+	Links                      Links     `json:"links,omitempty"`
+}
+
+// FormsResp is a response recieved with forms in it.
+type FormsResp struct {
+	StatusCode int         `json:"status_code,omitempty"`
+	Links      Links       `json:"links,omitempty"`
+	Meta       Meta        `json:"meta,omitempty"`
+	Forms      []*Form     `json:"forms,omitempty"`
+	Errors     []CodeError `json:"errors,omitempty"`
+}
+
+// ListForms returns a list of forms.
+func (c *Client) ListForms() (*FormsResp, error) {
+	url := fmt.Sprintf("%s%s/forms", baseURL, c.accountID)
+	resp := new(FormsResp)
+	httpResp, err := c.do(&Request{Operation: "ListForms", Method: http.MethodGet, URL: url, Body: nil}, resp)
+	if httpResp == nil {
+		return nil, err
+	}
+	resp.StatusCode = httpResp.StatusCode
+	return resp, err
+}
+
+// FetchForm fetches a form.
+func (c *Client) FetchForm(formID string) (*FormsResp, error) {
+	if formID == "" {
+		return nil, ErrInvalidInput
+	}
+	url := fmt.Sprintf("%s%s/forms/%s", baseURL, c.accountID, formID)
+	resp := new(FormsResp)
+	httpResp, err := c.do(&Request{Operation: "FetchForm", Method: http.MethodGet, URL: url, Body: nil}, resp)
+	if httpResp == nil {
+		return nil, err
+	}
+	resp.StatusCode = httpResp.StatusCode
+	return resp, err
+}
+
+// SubscriberForms fetches the forms listed in a subscriber's Links.Forms.
+// The first Drip API error is returned as a CodeError.
+func (c *Client) SubscriberForms(sub *Subscriber) ([]*Form, error) {
+	if sub == nil {
+		return nil, ErrInvalidInput
+	}
+	forms := make([]*Form, 0, len(sub.Links.Forms))
+	for _, formID := range sub.Links.Forms {
+		resp, err := c.FetchForm(formID)
+		if err != nil {
+			return nil, err
+		}
+		if len(resp.Errors) > 0 {
+			return nil, resp.Errors[0]
+		}
+		forms = append(forms, resp.Forms...)
+	}
+	return forms, nil
+}
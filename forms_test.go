@@ -0,0 +1,39 @@
+package drip_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dynamite-jobs/drip-go"
+)
+
+func TestSubscriberForms(t *testing.T) {
+	dripClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v2/9999999/forms/111":
+			w.Write([]byte(`{"forms":[{"id":"111","headline":"Join us"}]}`))
+		case "/v2/9999999/forms/222":
+			w.Write([]byte(`{"forms":[{"id":"222","headline":"Get the guide"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":[{"code":"not_found_error","message":"The resource you requested was not found"}]}`))
+		}
+	})
+
+	sub := &drip.Subscriber{Email: testEmail, Links: drip.Links{Forms: []string{"111", "222"}}}
+	forms, err := dripClient.SubscriberForms(sub)
+	if err != nil {
+		t.Fatalf("failed to resolve forms: %s", err)
+	}
+	if len(forms) != 2 || forms[1].Headline != "Get the guide" {
+		t.Fatalf("unexpected forms %+v", forms)
+	}
+
+	sub.Links.Forms = []string{"111", "333"}
+	_, err = dripClient.SubscriberForms(sub)
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("failed to error on missing form: %v", err)
+	}
+}
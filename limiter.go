@@ -0,0 +1,57 @@
+package drip
+
+import (
+	"net/http"
+	"sync/atomic"
+)
+
+// ConcurrencyLimiter caps the number of API calls in flight. Calls over the limit wait for a free
+// slot or until their context is done.
+type ConcurrencyLimiter struct {
+	slots  chan struct{}
+	queued int64
+}
+
+// NewConcurrencyLimiter returns a ConcurrencyLimiter allowing max calls in flight.
+// Add it to a Client with Use(l.Middleware()).
+func NewConcurrencyLimiter(max int) *ConcurrencyLimiter {
+	if max < 1 {
+		max = 1
+	}
+	return &ConcurrencyLimiter{
+		slots: make(chan struct{}, max),
+	}
+}
+
+// InFlight returns the number of calls in flight.
+func (l *ConcurrencyLimiter) InFlight() int {
+	return len(l.slots)
+}
+
+// Queued returns the number of calls waiting for a slot.
+func (l *ConcurrencyLimiter) Queued() int {
+	return int(atomic.LoadInt64(&l.queued))
+}
+
+// Middleware returns the Middleware applying the limit. Add it after Retry so calls waiting to be
+// retried do not hold a slot.
+func (l *ConcurrencyLimiter) Middleware() Middleware {
+	return func(next Handler) Handler {
+		return func(req *Request) (*http.Response, error) {
+			select {
+			case l.slots <- struct{}{}:
+			default:
+				atomic.AddInt64(&l.queued, 1)
+				select {
+				case l.slots <- struct{}{}:
+					atomic.AddInt64(&l.queued, -1)
+				case <-req.Context.Done():
+					atomic.AddInt64(&l.queued, -1)
+					return nil, req.Context.Err()
+				}
+			}
+			defer func() { <-l.slots }()
+			return next(req)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package drip_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dynamite-jobs/drip-go"
+)
+
+func TestConcurrencyLimiter(t *testing.T) {
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var inFlight, maxInFlight int
+	dripClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		<-release
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	})
+	limiter := drip.NewConcurrencyLimiter(2)
+	dripClient.Use(limiter.Middleware())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := dripClient.DeleteSubscriber(testEmail); err != nil {
+				t.Errorf("failed to delete subscriber: %s", err)
+			}
+		}()
+	}
+	deadline := time.Now().Add(time.Second)
+	for limiter.Queued() != 3 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if limiter.InFlight() != 2 || limiter.Queued() != 3 {
+		t.Fatalf("unexpected in flight %d queued %d", limiter.InFlight(), limiter.Queued())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := dripClient.WithContext(ctx).DeleteSubscriber(testEmail); err != context.DeadlineExceeded {
+		t.Fatalf("failed to get DeadlineExceeded: %v", err)
+	}
+
+	close(release)
+	wg.Wait()
+	if maxInFlight != 2 {
+		t.Fatalf("expected at most 2 calls in flight, got %d", maxInFlight)
+	}
+	if limiter.InFlight() != 0 || limiter.Queued() != 0 {
+		t.Fatalf("unexpected in flight %d queued %d", limiter.InFlight(), limiter.Queued())
+	}
+}
@@ -0,0 +1,94 @@
+package drip
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// StructuredLogger is used by StructuredLogging. *slog.Logger satisfies it.
+type StructuredLogger interface {
+	DebugContext(ctx context.Context, msg string, args ...interface{})
+	InfoContext(ctx context.Context, msg string, args ...interface{})
+	WarnContext(ctx context.Context, msg string, args ...interface{})
+	ErrorContext(ctx context.Context, msg string, args ...interface{})
+}
+
+// LogOptions configures StructuredLogging.
+type LogOptions struct {
+	// HashEmails replaces emails with a short hash instead of [email] so calls for the same
+	// subscriber can be correlated.
+	HashEmails bool
+	// Debug also logs the redacted request and response bodies at debug level.
+	Debug bool
+}
+
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+(@|%40)[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+// redactEmails replaces every email in s with [email] or a hash of it.
+func redactEmails(s string, hash bool) string {
+	return emailPattern.ReplaceAllStringFunc(s, func(email string) string {
+		if !hash {
+			return "[email]"
+		}
+		email = strings.ToLower(strings.Replace(email, "%40", "@", 1))
+		sum := sha256.Sum256([]byte(email))
+		return "email:" + hex.EncodeToString(sum[:6])
+	})
+}
+
+// StructuredLogging logs every API call with its operation, method, path, status, latency,
+// remaining rate limit and Drip error codes. Emails are redacted from paths and bodies.
+// Calls are logged at info level, 4xx responses at warn and 5xx responses and failures at error.
+func StructuredLogging(logger StructuredLogger, opts LogOptions) Middleware {
+	return func(next Handler) Handler {
+		return func(req *Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next(req)
+			args := []interface{}{
+				"operation", req.Operation,
+				"account_id", req.AccountID,
+				"method", req.Method,
+				"path", redactEmails(req.Path, opts.HashEmails),
+				"latency", time.Since(start),
+			}
+			if req.Attempt > 0 {
+				args = append(args, "retries", req.Attempt)
+			}
+			if opts.Debug && req.Body != nil {
+				if b, jerr := json.Marshal(req.Body); jerr == nil {
+					logger.DebugContext(req.Context, "drip request body", "operation", req.Operation, "body", redactEmails(string(b), opts.HashEmails))
+				}
+			}
+			if err != nil {
+				args = append(args, "error", redactEmails(err.Error(), opts.HashEmails))
+				logger.ErrorContext(req.Context, "drip request failed", args...)
+				return resp, err
+			}
+			args = append(args, "status", resp.StatusCode)
+			if remaining := resp.Header.Get("X-RateLimit-Remaining"); remaining != "" {
+				args = append(args, "rate_limit_remaining", remaining)
+			}
+			if codes := errorCodes(resp); len(codes) > 0 {
+				args = append(args, "error_codes", codes)
+			}
+			if opts.Debug {
+				logger.DebugContext(req.Context, "drip response body", "operation", req.Operation, "body", redactEmails(string(peekBody(resp)), opts.HashEmails))
+			}
+			switch {
+			case resp.StatusCode >= 500:
+				logger.ErrorContext(req.Context, "drip request", args...)
+			case resp.StatusCode >= 400:
+				logger.WarnContext(req.Context, "drip request", args...)
+			default:
+				logger.InfoContext(req.Context, "drip request", args...)
+			}
+			return resp, err
+		}
+	}
+}
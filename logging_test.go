@@ -0,0 +1,89 @@
+package drip_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dynamite-jobs/drip-go"
+)
+
+type logRecord struct {
+	level string
+	msg   string
+	attrs map[string]string
+}
+
+type fakeLogger struct {
+	records []logRecord
+}
+
+func (l *fakeLogger) log(level, msg string, args []interface{}) {
+	attrs := make(map[string]string)
+	for i := 0; i+1 < len(args); i += 2 {
+		attrs[fmt.Sprint(args[i])] = fmt.Sprint(args[i+1])
+	}
+	l.records = append(l.records, logRecord{level: level, msg: msg, attrs: attrs})
+}
+
+func (l *fakeLogger) DebugContext(ctx context.Context, msg string, args ...interface{}) {
+	l.log("debug", msg, args)
+}
+
+func (l *fakeLogger) InfoContext(ctx context.Context, msg string, args ...interface{}) {
+	l.log("info", msg, args)
+}
+
+func (l *fakeLogger) WarnContext(ctx context.Context, msg string, args ...interface{}) {
+	l.log("warn", msg, args)
+}
+
+func (l *fakeLogger) ErrorContext(ctx context.Context, msg string, args ...interface{}) {
+	l.log("error", msg, args)
+}
+
+func TestStructuredLogging(t *testing.T) {
+	dripClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-RateLimit-Remaining", "3599")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"errors":[{"code":"email_error","attribute":"email","message":"` + testEmail + ` is not valid"}]}`))
+	})
+	logger := &fakeLogger{}
+	dripClient.Use(drip.StructuredLogging(logger, drip.LogOptions{HashEmails: true, Debug: true}))
+
+	_, err := dripClient.UpdateSubscriber(&drip.UpdateSubscribersReq{
+		Subscribers: []drip.UpdateSubscriber{{Email: testEmail}},
+	})
+	if err != nil {
+		t.Fatalf("failed to update subscriber: %s", err)
+	}
+	if len(logger.records) != 3 {
+		t.Fatalf("expected 3 log records, got %+v", logger.records)
+	}
+	for _, record := range logger.records {
+		for _, v := range record.attrs {
+			if strings.Contains(v, testEmail) {
+				t.Fatalf("email leaked in %+v", record)
+			}
+		}
+	}
+	if !strings.Contains(logger.records[0].attrs["body"], "email:") {
+		t.Fatalf("expected hashed email in request body, got %+v", logger.records[0])
+	}
+	call := logger.records[2]
+	if call.level != "warn" || call.attrs["operation"] != "UpdateSubscriber" || call.attrs["status"] != "422" {
+		t.Fatalf("unexpected record %+v", call)
+	}
+	if call.attrs["rate_limit_remaining"] != "3599" || call.attrs["error_codes"] != "[email_error]" {
+		t.Fatalf("unexpected record %+v", call)
+	}
+
+	logger.records = nil
+	dripClient.FetchSubscriber(testEmail)
+	if got := logger.records[len(logger.records)-1].attrs["path"]; strings.Contains(got, "@") || !strings.HasPrefix(got, "/v2/9999999/subscribers/email:") {
+		t.Fatalf("unexpected path %q", got)
+	}
+}
@@ -0,0 +1,47 @@
+package drip
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Metrics records measurements of API calls. The dripprom package adapts Prometheus.
+// There are no events buffered or dropped metrics because the Client has no event buffer:
+// RecordEvent sends every event right away, so a lost event shows up as a failed "RecordEvent"
+// request in ObserveRequest.
+type Metrics interface {
+	// ObserveRequest records a finished API call. statusCode is 0 if no response was recieved.
+	ObserveRequest(operation string, statusCode int, latency time.Duration)
+	// ObserveRetries records the retries made by an API call.
+	ObserveRetries(operation string, retries int)
+	// SetRateLimitRemaining records the requests left in the rate limit window of an account.
+	SetRateLimitRemaining(accountID string, remaining int)
+	// ObserveBatchSize records the number of records sent by a batch operation.
+	ObserveBatchSize(operation string, size int)
+}
+
+// Measure records every API call in m. Add it before Retry so retries are counted.
+func Measure(m Metrics) Middleware {
+	return func(next Handler) Handler {
+		return func(req *Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next(req)
+			statusCode := 0
+			if resp != nil {
+				statusCode = resp.StatusCode
+				if remaining, perr := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining")); perr == nil {
+					m.SetRateLimitRemaining(req.AccountID, remaining)
+				}
+			}
+			m.ObserveRequest(req.Operation, statusCode, time.Since(start))
+			if req.Attempt > 0 {
+				m.ObserveRetries(req.Operation, req.Attempt)
+			}
+			if req.BatchSize > 0 {
+				m.ObserveBatchSize(req.Operation, req.BatchSize)
+			}
+			return resp, err
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package drip_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/dynamite-jobs/drip-go"
+)
+
+type fakeMetrics struct {
+	requests  map[string]int
+	retries   map[string]int
+	remaining map[string]int
+	batches   map[string]int
+}
+
+func (m *fakeMetrics) ObserveRequest(operation string, statusCode int, latency time.Duration) {
+	m.requests[operation+" "+http.StatusText(statusCode)]++
+}
+
+func (m *fakeMetrics) ObserveRetries(operation string, retries int) {
+	m.retries[operation] += retries
+}
+
+func (m *fakeMetrics) SetRateLimitRemaining(accountID string, remaining int) {
+	m.remaining[accountID] = remaining
+}
+
+func (m *fakeMetrics) ObserveBatchSize(operation string, size int) {
+	m.batches[operation] = size
+}
+
+func TestMeasure(t *testing.T) {
+	var calls int
+	dripClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("X-RateLimit-Remaining", "3590")
+		if calls == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"request_ids":["a","b"]}`))
+	})
+	m := &fakeMetrics{
+		requests:  make(map[string]int),
+		retries:   make(map[string]int),
+		remaining: make(map[string]int),
+		batches:   make(map[string]int),
+	}
+	dripClient.Use(drip.Measure(m), drip.Retry(2, time.Millisecond, time.Second))
+	_, err := dripClient.RecordOrderActivityBatch(&drip.OrderActivityBatchReq{
+		Orders: []drip.Order{
+			{Provider: "my_store", Email: testEmail, Action: drip.OrderPlaced, OrderID: "1"},
+			{Provider: "my_store", Email: testEmail, Action: drip.OrderPlaced, OrderID: "2"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to record orders: %s", err)
+	}
+	if m.requests["RecordOrderActivityBatch Accepted"] != 1 || len(m.requests) != 1 {
+		t.Fatalf("unexpected requests %v", m.requests)
+	}
+	if m.retries["RecordOrderActivityBatch"] != 1 {
+		t.Fatalf("unexpected retries %v", m.retries)
+	}
+	if m.remaining["9999999"] != 3590 {
+		t.Fatalf("unexpected rate limit remaining %v", m.remaining)
+	}
+	if m.batches["RecordOrderActivityBatch"] != 2 {
+		t.Fatalf("unexpected batch sizes %v", m.batches)
+	}
+}
@@ -0,0 +1,253 @@
+package drip
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Request is an API call passed through the middleware chain.
+type Request struct {
+	Context context.Context
+	// Operation is the name of the Client method, e.g. "UpdateSubscriber".
+	Operation string
+	AccountID string
+	Method    string
+	URL       string
+	Path      string
+	// Body is the query for GET requests and the JSON body otherwise.
+	Body interface{}
+	// Header is added to the http request.
+	Header http.Header
+	// Attempt is 0 for the first try and counts up on retries.
+	Attempt int
+	// BatchSize is the number of records sent by batch operations.
+	BatchSize int
+}
+
+// ErrNoResponse is returned if the middleware chain returns neither a response nor an error.
+var ErrNoResponse = errors.New("drip middleware returned no response")
+
+// Handler sends a Request. It must return a response or an error. The body of the returned
+// response is buffered so middleware may read it.
+type Handler func(req *Request) (*http.Response, error)
+
+// Middleware wraps a Handler to run code around every API call.
+type Middleware func(next Handler) Handler
+
+// Use adds middleware to the Client. The first middleware added runs first.
+func (c *Client) Use(mw ...Middleware) {
+	c.middleware = append(c.middleware[:len(c.middleware):len(c.middleware)], mw...)
+}
+
+// WithContext returns a Client whose API calls use ctx.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	cc := *c
+	cc.ctx = ctx
+	return &cc
+}
+
+// accountlessOperations are the operations that may be called without an account ID.
+var accountlessOperations = map[string]bool{
+	"ListAccounts": true,
+	"FetchAccount": true,
+	"FetchUser":    true,
+}
+
+// do sends req through the middleware chain and decodes the response into v.
+// The response is nil if no response was recieved.
+// Account scoped operations fail with ErrBadAccountID on a Client without an account.
+func (c *Client) do(req *Request, v interface{}) (*http.Response, error) {
+	if c.accountID == "" && !accountlessOperations[req.Operation] {
+		return nil, ErrBadAccountID
+	}
+	if req.Context == nil {
+		req.Context = c.ctx
+	}
+	if req.Context == nil {
+		req.Context = context.Background()
+	}
+	if req.AccountID == "" {
+		req.AccountID = c.accountID
+	}
+	if req.Path == "" {
+		if u, err := url.Parse(req.URL); err == nil {
+			req.Path = u.Path
+		}
+	}
+	if req.Header == nil {
+		req.Header = make(http.Header)
+	}
+	h := c.send
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		h = c.middleware[i](h)
+	}
+	httpResp, err := h(req)
+	if err != nil {
+		return httpResp, err
+	}
+	if httpResp == nil {
+		return nil, ErrNoResponse
+	}
+	defer httpResp.Body.Close()
+	return httpResp, c.decodeResp(httpResp, v)
+}
+
+// send is the last Handler of the chain.
+func (c *Client) send(req *Request) (*http.Response, error) {
+	httpReq, err := c.getReq(req.Context, req.Method, req.URL, req.Body)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range req.Header {
+		for _, value := range values {
+			httpReq.Header.Add(key, value)
+		}
+	}
+	httpResp, err := c.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+	b, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, err
+	}
+	httpResp.Body = &bufferedBody{bytes.NewReader(b)}
+	return httpResp, nil
+}
+
+// bufferedBody is a response body that can be reread by middleware.
+type bufferedBody struct {
+	*bytes.Reader
+}
+
+func (b *bufferedBody) Close() error {
+	return nil
+}
+
+// peekBody returns the response body without consuming it.
+func peekBody(resp *http.Response) []byte {
+	body, ok := resp.Body.(*bufferedBody)
+	if !ok {
+		return nil
+	}
+	b, _ := ioutil.ReadAll(body)
+	body.Seek(0, 0)
+	return b
+}
+
+// responseErrors returns the Drip errors in the response body.
+func responseErrors(resp *http.Response) []CodeError {
+	var r Response
+	if json.Unmarshal(peekBody(resp), &r) != nil {
+		return nil
+	}
+	return r.Errors
+}
+
+// errorCodes returns the codes of the Drip errors in the response body.
+func errorCodes(resp *http.Response) []string {
+	var codes []string
+	for _, codeErr := range responseErrors(resp) {
+		codes = append(codes, codeErr.Code)
+	}
+	return codes
+}
+
+// Logger is used by Logging. *log.Logger satisfies it.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// Logging logs the operation, method, path, status and latency of every API call.
+// Emails in the path are redacted.
+func Logging(logger Logger) Middleware {
+	return func(next Handler) Handler {
+		return func(req *Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next(req)
+			latency := time.Since(start)
+			if err != nil {
+				logger.Printf("drip: %s %s %s error=%q latency=%s", req.Operation, req.Method, redactEmails(req.Path, false), redactEmails(err.Error(), false), latency)
+				return resp, err
+			}
+			logger.Printf("drip: %s %s %s status=%d latency=%s", req.Operation, req.Method, redactEmails(req.Path, false), resp.StatusCode, latency)
+			return resp, err
+		}
+	}
+}
+
+// Observer is called by Observe after every API call. statusCode is 0 if no response was recieved.
+type Observer func(req *Request, statusCode int, latency time.Duration, err error)
+
+// Observe calls observer after every API call, e.g. to record metrics.
+func Observe(observer Observer) Middleware {
+	return func(next Handler) Handler {
+		return func(req *Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next(req)
+			statusCode := 0
+			if resp != nil {
+				statusCode = resp.StatusCode
+			}
+			observer(req, statusCode, time.Since(start), err)
+			return resp, err
+		}
+	}
+}
+
+// Retry retries API calls that fail with a network error, 429 or 5xx status up to maxRetries times.
+// It waits for the Retry-After header if sent, otherwise backoff doubled on every attempt.
+// It never waits longer than maxWait: the backoff is capped and a response whose Retry-After is
+// longer is returned as is. maxWait 0 means no limit.
+// Note that retried calls such as RecordEvent may be recorded twice if Drip recieved the first one.
+func Retry(maxRetries int, backoff, maxWait time.Duration) Middleware {
+	return func(next Handler) Handler {
+		return func(req *Request) (*http.Response, error) {
+			for {
+				resp, err := next(req)
+				if req.Attempt >= maxRetries || !shouldRetry(req, resp, err) {
+					return resp, err
+				}
+				wait := backoff << uint(req.Attempt)
+				if resp != nil {
+					if secs, perr := strconv.Atoi(resp.Header.Get("Retry-After")); perr == nil && secs >= 0 {
+						wait = time.Duration(secs) * time.Second
+						if maxWait > 0 && wait > maxWait {
+							return resp, err
+						}
+					}
+				}
+				if maxWait > 0 && wait > maxWait {
+					wait = maxWait
+				}
+				timer := time.NewTimer(wait)
+				select {
+				case <-req.Context.Done():
+					timer.Stop()
+					return nil, req.Context.Err()
+				case <-timer.C:
+				}
+				req.Attempt++
+			}
+		}
+	}
+}
+
+func shouldRetry(req *Request, resp *http.Response, err error) bool {
+	if req.Context.Err() != nil {
+		return false
+	}
+	if err != nil {
+		var urlErr *url.Error
+		return errors.As(err, &urlErr)
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
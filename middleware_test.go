@@ -0,0 +1,135 @@
+package drip_test
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dynamite-jobs/drip-go"
+)
+
+func TestMiddleware(t *testing.T) {
+	var gotRequestIDs []string
+	dripClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		gotRequestIDs = append(gotRequestIDs, r.Header.Get("X-Request-ID"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"subscribers":[{"email":"` + testEmail + `"}]}`))
+	})
+
+	var order []string
+	var ops []string
+	dripClient.Use(
+		func(next drip.Handler) drip.Handler {
+			return func(req *drip.Request) (*http.Response, error) {
+				order = append(order, "outer")
+				req.Header.Set("X-Request-ID", "req-1")
+				return next(req)
+			}
+		},
+		func(next drip.Handler) drip.Handler {
+			return func(req *drip.Request) (*http.Response, error) {
+				order = append(order, "inner")
+				ops = append(ops, req.Operation+" "+req.Method+" "+req.Path+" "+req.AccountID)
+				return next(req)
+			}
+		},
+	)
+	resp, err := dripClient.FetchSubscriber(testEmail)
+	if err != nil {
+		t.Fatalf("failed to fetch subscriber: %s", err)
+	}
+	if len(resp.Subscribers) != 1 {
+		t.Fatalf("unexpected subscribers %+v", resp.Subscribers)
+	}
+	if strings.Join(order, ",") != "outer,inner" {
+		t.Fatalf("unexpected middleware order %v", order)
+	}
+	if len(ops) != 1 || ops[0] != "FetchSubscriber GET /v2/9999999/subscribers/"+testEmail+" 9999999" {
+		t.Fatalf("unexpected request %v", ops)
+	}
+	if len(gotRequestIDs) != 1 || gotRequestIDs[0] != "req-1" {
+		t.Fatalf("unexpected request ids %v", gotRequestIDs)
+	}
+}
+
+func TestRetry(t *testing.T) {
+	var calls int
+	dripClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte("Too Many Requests"))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	var logs bytes.Buffer
+	var observed []int
+	dripClient.Use(
+		drip.Logging(log.New(&logs, "", 0)),
+		drip.Observe(func(req *drip.Request, statusCode int, latency time.Duration, err error) {
+			observed = append(observed, statusCode)
+		}),
+		drip.Retry(3, time.Millisecond, time.Second),
+	)
+	resp, err := dripClient.DeleteSubscriber(testEmail)
+	if err != nil {
+		t.Fatalf("failed to delete subscriber: %s", err)
+	}
+	if resp.StatusCode != http.StatusNoContent || calls != 3 {
+		t.Fatalf("unexpected status %d after %d calls", resp.StatusCode, calls)
+	}
+	if len(observed) != 1 || observed[0] != http.StatusNoContent {
+		t.Fatalf("unexpected observed statuses %v", observed)
+	}
+	if !strings.Contains(logs.String(), "DeleteSubscriber DELETE /v2/9999999/subscribers/[email] status=204") {
+		t.Fatalf("unexpected log %q", logs.String())
+	}
+
+	calls = 0
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := dripClient.WithContext(ctx).DeleteSubscriber(testEmail); err == nil {
+		t.Fatalf("failed to error on canceled context")
+	}
+	if calls != 0 {
+		t.Fatalf("expected no calls with canceled context, got %d", calls)
+	}
+}
+
+func TestRetryMaxWait(t *testing.T) {
+	var calls int
+	dripClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte("Too Many Requests"))
+	})
+	dripClient.Use(drip.Retry(3, time.Millisecond, time.Second))
+	start := time.Now()
+	if _, err := dripClient.DeleteSubscriber(testEmail); err == nil || !strings.Contains(err.Error(), "429") {
+		t.Fatalf("failed to get 429: %v", err)
+	}
+	if calls != 1 || time.Since(start) > time.Second {
+		t.Fatalf("unexpected %d calls in %s", calls, time.Since(start))
+	}
+}
+
+func TestMiddlewareNoResponse(t *testing.T) {
+	dripClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	dripClient.Use(func(next drip.Handler) drip.Handler {
+		return func(req *drip.Request) (*http.Response, error) {
+			return nil, nil
+		}
+	})
+	if _, err := dripClient.DeleteSubscriber(testEmail); err != drip.ErrNoResponse {
+		t.Fatalf("failed to get ErrNoResponse: %v", err)
+	}
+}
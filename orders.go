@@ -0,0 +1,227 @@
+package drip
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// LegacyAddress is a billing or shipping address on a LegacyOrder.
+type LegacyAddress struct {
+	Name      string `json:"name,omitempty"`
+	FirstName string `json:"first_name,omitempty"`
+	LastName  string `json:"last_name,omitempty"`
+	Company   string `json:"company,omitempty"`
+	Address1  string `json:"address_1,omitempty"`
+	Address2  string `json:"address_2,omitempty"`
+	City      string `json:"city,omitempty"`
+	State     string `json:"state,omitempty"`
+	Zip       string `json:"zip,omitempty"`
+	Country   string `json:"country,omitempty"`
+	Phone     string `json:"phone,omitempty"`
+	Email     string `json:"email,omitempty"`
+}
+
+// LegacyOrderItem is an item in a LegacyOrder. Amounts are in cents.
+type LegacyOrderItem struct {
+	ProductID                string                 `json:"product_id,omitempty"`
+	SKU                      string                 `json:"sku,omitempty"`
+	Amount                   int                    `json:"amount"`
+	Name                     string                 `json:"name"`
+	Quantity                 int                    `json:"quantity,omitempty"`
+	UpstreamID               string                 `json:"upstream_id,omitempty"`
+	UpstreamProductID        string                 `json:"upstream_product_id,omitempty"`
+	UpstreamProductVariantID string                 `json:"upstream_product_variant_id,omitempty"`
+	Price                    *int                   `json:"price,omitempty"`
+	Tax                      *int                   `json:"tax,omitempty"`
+	Fees                     *int                   `json:"fees,omitempty"`
+	Discount                 *int                   `json:"discount,omitempty"`
+	Taxable                  *bool                  `json:"taxable,omitempty"`
+	Properties               map[string]interface{} `json:"properties,omitempty"`
+}
+
+// LegacyOrder is an order for the v2 orders API. Amounts are in cents.
+// New integrations should use RecordOrderActivity instead.
+type LegacyOrder struct {
+	Email            string                 `json:"email,omitempty"`
+	ID               string                 `json:"id,omitempty"`
+	Provider         string                 `json:"provider,omitempty"`
+	UpstreamID       string                 `json:"upstream_id,omitempty"`
+	Identifier       string                 `json:"identifier,omitempty"`
+	Amount           int                    `json:"amount"`
+	Tax              *int                   `json:"tax,omitempty"`
+	Fees             *int                   `json:"fees,omitempty"`
+	Discount         *int                   `json:"discount,omitempty"`
+	Permalink        string                 `json:"permalink,omitempty"`
+	CurrencyCode     string                 `json:"currency_code,omitempty"`
+	Properties       map[string]interface{} `json:"properties,omitempty"`
+	OccurredAt       *time.Time             `json:"occurred_at,omitempty"`
+	ClosedAt         *time.Time             `json:"closed_at,omitempty"`
+	CancelledAt      *time.Time             `json:"cancelled_at,omitempty"`
+	FinancialState   string                 `json:"financial_state,omitempty"`
+	FulfillmentState string                 `json:"fulfillment_state,omitempty"`
+	BillingAddress   *LegacyAddress         `json:"billing_address,omitempty"`
+	ShippingAddress  *LegacyAddress         `json:"shipping_address,omitempty"`
+	Items            []LegacyOrderItem      `json:"items,omitempty"`
+}
+
+// OrdersReq is a request for CreateOrUpdateOrder.
+type OrdersReq struct {
+	Orders []LegacyOrder `json:"orders,omitempty"`
+}
+
+// CreateOrUpdateOrder creates or updates an order using the v2 orders API.
+// If you need to create or update a collection of orders at once, use CreateOrUpdateOrdersBatch instead.
+func (c *Client) CreateOrUpdateOrder(req *OrdersReq) (*Response, error) {
+	url := fmt.Sprintf("%s%s/orders", baseURL, c.accountID)
+	resp := new(Response)
+	httpResp, err := c.do(&Request{Operation: "CreateOrUpdateOrder", Method: http.MethodPost, URL: url, Body: req}, resp)
+	if httpResp == nil {
+		return nil, err
+	}
+	resp.StatusCode = httpResp.StatusCode
+	return resp, err
+}
+
+// OrdersBatch is a part of a OrdersBatchReq.
+type OrdersBatch struct {
+	Orders []LegacyOrder `json:"orders,omitempty"`
+}
+
+// OrdersBatchReq is a request for CreateOrUpdateOrdersBatch.
+type OrdersBatchReq struct {
+	Batches []OrdersBatch `json:"batches,omitempty"`
+}
+
+// CreateOrUpdateOrdersBatch creates or updates a collection of orders using the v2 orders API.
+// Note: Since our batch APIs process requests in the background, there may be a delay between the time you submit your request and the time your data appears in user interface.
+func (c *Client) CreateOrUpdateOrdersBatch(req *OrdersBatchReq) (*Response, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+	url := fmt.Sprintf("%s%s/orders/batches", baseURL, c.accountID)
+	batchSize := 0
+	for _, batch := range req.Batches {
+		batchSize += len(batch.Orders)
+	}
+	resp := new(Response)
+	httpResp, err := c.do(&Request{Operation: "CreateOrUpdateOrdersBatch", Method: http.MethodPost, URL: url, Body: req, BatchSize: batchSize}, resp)
+	if httpResp == nil {
+		return nil, err
+	}
+	resp.StatusCode = httpResp.StatusCode
+	return resp, err
+}
+
+// Refund is a refund of a LegacyOrder. Amount is in cents.
+type Refund struct {
+	Provider        string     `json:"provider"`
+	OrderUpstreamID string     `json:"order_upstream_id"`
+	Amount          int        `json:"amount"`
+	UpstreamID      string     `json:"upstream_id,omitempty"`
+	Note            string     `json:"note,omitempty"`
+	ProcessedAt     *time.Time `json:"processed_at,omitempty"`
+}
+
+// RefundsReq is a request for CreateRefund.
+type RefundsReq struct {
+	Refunds []Refund `json:"refunds,omitempty"`
+}
+
+// CreateRefund creates or updates a refund for an order.
+func (c *Client) CreateRefund(req *RefundsReq) (*Response, error) {
+	url := fmt.Sprintf("%s%s/refunds", baseURL, c.accountID)
+	resp := new(Response)
+	httpResp, err := c.do(&Request{Operation: "CreateRefund", Method: http.MethodPost, URL: url, Body: req}, resp)
+	if httpResp == nil {
+		return nil, err
+	}
+	resp.StatusCode = httpResp.StatusCode
+	return resp, err
+}
+
+// PurchaseItem is an item in a Purchase. Amounts are in cents.
+type PurchaseItem struct {
+	ProductID  string                 `json:"product_id,omitempty"`
+	SKU        string                 `json:"sku,omitempty"`
+	Name       string                 `json:"name"`
+	Amount     int                    `json:"amount"`
+	Price      *int                   `json:"price,omitempty"`
+	Quantity   int                    `json:"quantity,omitempty"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+// Purchase is a purchase made by a subscriber. Amount is in cents.
+type Purchase struct {
+	ID         string                 `json:"id,omitempty"`
+	Amount     int                    `json:"amount"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+	Items      []PurchaseItem         `json:"items,omitempty"`
+	Provider   string                 `json:"provider,omitempty"`
+	Reference  string                 `json:"reference,omitempty"`
+	OccurredAt *time.Time             `json:"occurred_at,omitempty"`
+	HREF       string                 `json:"href,omitempty"`
+	Links      Links                  `json:"links,omitempty"`
+}
+
+// PurchasesReq is a request for CreatePurchase.
+type PurchasesReq struct {
+	Purchases []Purchase `json:"purchases,omitempty"`
+}
+
+// PurchasesResp is a response recieved with purchases in it.
+type PurchasesResp struct {
+	StatusCode int         `json:"status_code,omitempty"`
+	Links      Links       `json:"links,omitempty"`
+	Meta       Meta        `json:"meta,omitempty"`
+	Purchases  []*Purchase `json:"purchases,omitempty"`
+	Errors     []CodeError `json:"errors,omitempty"`
+}
+
+// CreatePurchase records a purchase for a subscriber.
+func (c *Client) CreatePurchase(idOrEmail string, req *PurchasesReq) (*PurchasesResp, error) {
+	if idOrEmail == "" {
+		return nil, ErrIDorEmailEmpty
+	}
+	url := fmt.Sprintf("%s%s/subscribers/%s/purchases", baseURL, c.accountID, idOrEmail)
+	resp := new(PurchasesResp)
+	httpResp, err := c.do(&Request{Operation: "CreatePurchase", Method: http.MethodPost, URL: url, Body: req}, resp)
+	if httpResp == nil {
+		return nil, err
+	}
+	resp.StatusCode = httpResp.StatusCode
+	return resp, err
+}
+
+// ListPurchases returns a list of purchases for a subscriber.
+func (c *Client) ListPurchases(idOrEmail string) (*PurchasesResp, error) {
+	if idOrEmail == "" {
+		return nil, ErrIDorEmailEmpty
+	}
+	url := fmt.Sprintf("%s%s/subscribers/%s/purchases", baseURL, c.accountID, idOrEmail)
+	resp := new(PurchasesResp)
+	httpResp, err := c.do(&Request{Operation: "ListPurchases", Method: http.MethodGet, URL: url, Body: nil}, resp)
+	if httpResp == nil {
+		return nil, err
+	}
+	resp.StatusCode = httpResp.StatusCode
+	return resp, err
+}
+
+// FetchPurchase fetches a purchase for a subscriber.
+func (c *Client) FetchPurchase(idOrEmail, purchaseID string) (*PurchasesResp, error) {
+	if idOrEmail == "" {
+		return nil, ErrIDorEmailEmpty
+	}
+	if purchaseID == "" {
+		return nil, ErrInvalidInput
+	}
+	url := fmt.Sprintf("%s%s/subscribers/%s/purchases/%s", baseURL, c.accountID, idOrEmail, purchaseID)
+	resp := new(PurchasesResp)
+	httpResp, err := c.do(&Request{Operation: "FetchPurchase", Method: http.MethodGet, URL: url, Body: nil}, resp)
+	if httpResp == nil {
+		return nil, err
+	}
+	resp.StatusCode = httpResp.StatusCode
+	return resp, err
+}
@@ -0,0 +1,82 @@
+package drip_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/dynamite-jobs/drip-go"
+)
+
+func TestListPurchases(t *testing.T) {
+	tables := []struct {
+		idOrEmail string
+		resp      *mockResp
+		minPurch  int
+	}{
+		{
+			idOrEmail: testEmail,
+			resp: &mockResp{
+				desc:         "failed to list purchases",
+				hasError:     false,
+				minCodeError: 0,
+			},
+			minPurch: 1,
+		},
+		{
+			idOrEmail: "",
+			resp: &mockResp{
+				desc:         "failed to error on no id or email",
+				hasError:     true,
+				minCodeError: 0,
+			},
+		},
+	}
+
+	dripClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v2/9999999/subscribers/"+testEmail+"/purchases" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"purchases":[{"id":"123","amount":2000,"provider":"my_store","items":[{"name":"Coffee","amount":2000}]}]}`))
+	})
+	for _, table := range tables {
+		resp, err := dripClient.ListPurchases(table.idOrEmail)
+		if err != nil && table.resp.hasError != true {
+			t.Fatalf("hasError %s: %s", table.resp.desc, err)
+		}
+		if resp != nil && len(resp.Errors) < table.resp.minCodeError {
+			t.Fatalf("minCodeError %s", table.resp.desc)
+		}
+		if resp != nil && len(resp.Purchases) < table.minPurch {
+			t.Fatalf("minPurch %s", table.resp.desc)
+		}
+		if resp != nil && resp.Purchases[0].Items[0].Amount != 2000 {
+			t.Fatalf("bad item amount %s", table.resp.desc)
+		}
+	}
+}
+
+func TestCreateOrUpdateOrder(t *testing.T) {
+	dripClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v2/9999999/orders" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{}`))
+	})
+	resp, err := dripClient.CreateOrUpdateOrder(&drip.OrdersReq{
+		Orders: []drip.LegacyOrder{
+			{Email: testEmail, Provider: "my_store", UpstreamID: "abcdef", Amount: 4900},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create order: %s", err)
+	}
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("unexpected status code %d", resp.StatusCode)
+	}
+	if _, err := dripClient.CreateOrUpdateOrdersBatch(nil); err != drip.ErrInvalidInput {
+		t.Fatalf("failed to get ErrInvalidInput: %v", err)
+	}
+}
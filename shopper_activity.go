@@ -0,0 +1,302 @@
+package drip
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const baseURLv3 = "https://api.getdrip.com/v3/"
+
+// CartAction is the action recorded for a cart.
+type CartAction string
+
+const (
+	// CartCreated is sent when a cart is created.
+	CartCreated CartAction = "created"
+	// CartUpdated is sent when a cart is updated.
+	CartUpdated CartAction = "updated"
+)
+
+// OrderAction is the action recorded for an order.
+type OrderAction string
+
+const (
+	// OrderPlaced is sent when an order is placed.
+	OrderPlaced OrderAction = "placed"
+	// OrderUpdated is sent when an order is updated.
+	OrderUpdated OrderAction = "updated"
+	// OrderPaid is sent when an order is paid.
+	OrderPaid OrderAction = "paid"
+	// OrderFulfilled is sent when an order is fulfilled.
+	OrderFulfilled OrderAction = "fulfilled"
+	// OrderRefunded is sent when an order is refunded.
+	OrderRefunded OrderAction = "refunded"
+	// OrderCanceled is sent when an order is canceled.
+	OrderCanceled OrderAction = "canceled"
+)
+
+// ProductAction is the action recorded for a product.
+type ProductAction string
+
+const (
+	// ProductCreated is sent when a product is created.
+	ProductCreated ProductAction = "created"
+	// ProductUpdated is sent when a product is updated.
+	ProductUpdated ProductAction = "updated"
+	// ProductDeleted is sent when a product is deleted.
+	ProductDeleted ProductAction = "deleted"
+)
+
+// Money is an amount in hundredths of the currency unit (e.g. cents).
+// It is sent to Drip as a decimal number so no precision is lost to floats.
+type Money int64
+
+// String returns the amount as a decimal, e.g. Money(1999) is "19.99".
+func (m Money) String() string {
+	sign := ""
+	v := int64(m)
+	if v < 0 {
+		sign = "-"
+		v = -v
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, v/100, v%100)
+}
+
+// MarshalJSON encodes the amount as a JSON number.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON decodes a JSON number or string with at most two decimals.
+func (m *Money) UnmarshalJSON(b []byte) error {
+	s := string(b)
+	if s == "null" {
+		return nil
+	}
+	if strings.HasPrefix(s, `"`) {
+		if len(s) < 2 || !strings.HasSuffix(s, `"`) {
+			return fmt.Errorf("drip: invalid money %q", string(b))
+		}
+		s = s[1 : len(s)-1]
+		if s == "" {
+			return nil
+		}
+	}
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	parts := strings.SplitN(s, ".", 2)
+	if !isDigits(parts[0]) {
+		return fmt.Errorf("drip: invalid money %q", string(b))
+	}
+	whole, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("drip: invalid money %q", string(b))
+	}
+	var frac int64
+	if len(parts) == 2 {
+		if !isDigits(parts[1]) {
+			return fmt.Errorf("drip: invalid money %q", string(b))
+		}
+		f := strings.TrimRight(parts[1], "0")
+		if len(f) > 2 {
+			return fmt.Errorf("drip: money %q has more than two decimals", string(b))
+		}
+		f += strings.Repeat("0", 2-len(f))
+		frac, err = strconv.ParseInt(f, 10, 64)
+		if err != nil {
+			return fmt.Errorf("drip: invalid money %q", string(b))
+		}
+	}
+	v := whole*100 + frac
+	if neg {
+		v = -v
+	}
+	*m = Money(v)
+	return nil
+}
+
+// isDigits reports whether s is a non-empty string of ASCII digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// LineItem is an item in a Cart or Order.
+type LineItem struct {
+	ProductID        string   `json:"product_id,omitempty"`
+	ProductVariantID string   `json:"product_variant_id,omitempty"`
+	SKU              string   `json:"sku,omitempty"`
+	Name             string   `json:"name,omitempty"`
+	Brand            string   `json:"brand,omitempty"`
+	Categories       []string `json:"categories,omitempty"`
+	Price            Money    `json:"price"`
+	SalePrice        *Money   `json:"sale_price,omitempty"`
+	Quantity         int      `json:"quantity,omitempty"`
+	Discounts        *Money   `json:"discounts,omitempty"`
+	Taxes            *Money   `json:"taxes,omitempty"`
+	Fees             *Money   `json:"fees,omitempty"`
+	Shipping         *Money   `json:"shipping,omitempty"`
+	Total            *Money   `json:"total,omitempty"`
+	ProductURL       string   `json:"product_url,omitempty"`
+	ImageURL         string   `json:"image_url,omitempty"`
+}
+
+// Address is a billing or shipping address on an Order.
+type Address struct {
+	Label      string `json:"label,omitempty"`
+	FirstName  string `json:"first_name,omitempty"`
+	LastName   string `json:"last_name,omitempty"`
+	Company    string `json:"company,omitempty"`
+	Address1   string `json:"address_1,omitempty"`
+	Address2   string `json:"address_2,omitempty"`
+	City       string `json:"city,omitempty"`
+	State      string `json:"state,omitempty"`
+	PostalCode string `json:"postal_code,omitempty"`
+	Country    string `json:"country,omitempty"`
+	Phone      string `json:"phone,omitempty"`
+}
+
+// Cart is a cart sent to RecordCartActivity.
+type Cart struct {
+	Provider       string     `json:"provider"`
+	Email          string     `json:"email,omitempty"`
+	PersonID       string     `json:"person_id,omitempty"`
+	Action         CartAction `json:"action"`
+	CartID         string     `json:"cart_id"`
+	OccurredAt     *time.Time `json:"occurred_at,omitempty"`
+	GrandTotal     *Money     `json:"grand_total,omitempty"`
+	TotalDiscounts *Money     `json:"total_discounts,omitempty"`
+	Currency       string     `json:"currency,omitempty"`
+	CartURL        string     `json:"cart_url,omitempty"`
+	Items          []LineItem `json:"items,omitempty"`
+}
+
+// Order is an order sent to RecordOrderActivity.
+type Order struct {
+	Provider        string      `json:"provider"`
+	Email           string      `json:"email,omitempty"`
+	PersonID        string      `json:"person_id,omitempty"`
+	Action          OrderAction `json:"action"`
+	OrderID         string      `json:"order_id"`
+	OrderPublicID   string      `json:"order_public_id,omitempty"`
+	OccurredAt      *time.Time  `json:"occurred_at,omitempty"`
+	GrandTotal      *Money      `json:"grand_total,omitempty"`
+	TotalDiscounts  *Money      `json:"total_discounts,omitempty"`
+	TotalTaxes      *Money      `json:"total_taxes,omitempty"`
+	TotalFees       *Money      `json:"total_fees,omitempty"`
+	TotalShipping   *Money      `json:"total_shipping,omitempty"`
+	RefundAmount    *Money      `json:"refund_amount,omitempty"`
+	Currency        string      `json:"currency,omitempty"`
+	OrderURL        string      `json:"order_url,omitempty"`
+	Items           []LineItem  `json:"items,omitempty"`
+	BillingAddress  *Address    `json:"billing_address,omitempty"`
+	ShippingAddress *Address    `json:"shipping_address,omitempty"`
+}
+
+// Product is a product sent to RecordProductActivity.
+type Product struct {
+	Provider         string        `json:"provider"`
+	Action           ProductAction `json:"action"`
+	OccurredAt       *time.Time    `json:"occurred_at,omitempty"`
+	ProductID        string        `json:"product_id"`
+	ProductVariantID string        `json:"product_variant_id,omitempty"`
+	SKU              string        `json:"sku,omitempty"`
+	Name             string        `json:"name"`
+	Brand            string        `json:"brand,omitempty"`
+	Categories       []string      `json:"categories,omitempty"`
+	Price            Money         `json:"price"`
+	Inventory        *int          `json:"inventory,omitempty"`
+	ProductURL       string        `json:"product_url,omitempty"`
+	ImageURL         string        `json:"image_url,omitempty"`
+}
+
+// ShopperActivityResp is a response recieved from the shopper activity API.
+// Drip accepts the activity and processes it in the background, so only request IDs are returned.
+type ShopperActivityResp struct {
+	StatusCode int         `json:"status_code,omitempty"`
+	RequestID  string      `json:"request_id,omitempty"`
+	RequestIDs []string    `json:"request_ids,omitempty"`
+	Errors     []CodeError `json:"errors,omitempty"`
+}
+
+// RecordCartActivity records a cart being created or updated.
+func (c *Client) RecordCartActivity(req *Cart) (*ShopperActivityResp, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+	if req.Email == "" && req.PersonID == "" {
+		return nil, ErrIDorEmailEmpty
+	}
+	url := fmt.Sprintf("%s%s/shopper_activity/cart", baseURLv3, c.accountID)
+	resp := new(ShopperActivityResp)
+	httpResp, err := c.do(&Request{Operation: "RecordCartActivity", Method: http.MethodPost, URL: url, Body: req}, resp)
+	if httpResp == nil {
+		return nil, err
+	}
+	resp.StatusCode = httpResp.StatusCode
+	return resp, err
+}
+
+// RecordOrderActivity records an order event such as placed, paid or refunded.
+// If you need to record a collection of orders at once, use RecordOrderActivityBatch instead.
+func (c *Client) RecordOrderActivity(req *Order) (*ShopperActivityResp, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+	if req.Email == "" && req.PersonID == "" {
+		return nil, ErrIDorEmailEmpty
+	}
+	url := fmt.Sprintf("%s%s/shopper_activity/order", baseURLv3, c.accountID)
+	resp := new(ShopperActivityResp)
+	httpResp, err := c.do(&Request{Operation: "RecordOrderActivity", Method: http.MethodPost, URL: url, Body: req}, resp)
+	if httpResp == nil {
+		return nil, err
+	}
+	resp.StatusCode = httpResp.StatusCode
+	return resp, err
+}
+
+// OrderActivityBatchReq is a request for RecordOrderActivityBatch.
+type OrderActivityBatchReq struct {
+	Orders []Order `json:"orders,omitempty"`
+}
+
+// RecordOrderActivityBatch records up to 1000 orders at once.
+func (c *Client) RecordOrderActivityBatch(req *OrderActivityBatchReq) (*ShopperActivityResp, error) {
+	if req == nil || len(req.Orders) == 0 {
+		return nil, ErrInvalidInput
+	}
+	url := fmt.Sprintf("%s%s/shopper_activity/order/batch", baseURLv3, c.accountID)
+	resp := new(ShopperActivityResp)
+	httpResp, err := c.do(&Request{Operation: "RecordOrderActivityBatch", Method: http.MethodPost, URL: url, Body: req, BatchSize: len(req.Orders)}, resp)
+	if httpResp == nil {
+		return nil, err
+	}
+	resp.StatusCode = httpResp.StatusCode
+	return resp, err
+}
+
+// RecordProductActivity records a product being created, updated or deleted.
+func (c *Client) RecordProductActivity(req *Product) (*ShopperActivityResp, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+	url := fmt.Sprintf("%s%s/shopper_activity/product", baseURLv3, c.accountID)
+	resp := new(ShopperActivityResp)
+	httpResp, err := c.do(&Request{Operation: "RecordProductActivity", Method: http.MethodPost, URL: url, Body: req}, resp)
+	if httpResp == nil {
+		return nil, err
+	}
+	resp.StatusCode = httpResp.StatusCode
+	return resp, err
+}
@@ -0,0 +1,120 @@
+package drip_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/dynamite-jobs/drip-go"
+)
+
+func TestMoney(t *testing.T) {
+	tables := []struct {
+		money drip.Money
+		json  string
+	}{
+		{money: 1999, json: "19.99"},
+		{money: 5, json: "0.05"},
+		{money: -150, json: "-1.50"},
+		{money: 0, json: "0.00"},
+	}
+	for _, table := range tables {
+		b, err := json.Marshal(table.money)
+		if err != nil {
+			t.Fatalf("failed to marshal %d: %s", table.money, err)
+		}
+		if string(b) != table.json {
+			t.Fatalf("marshal %d got %s want %s", table.money, b, table.json)
+		}
+		var m drip.Money
+		if err := json.Unmarshal(b, &m); err != nil {
+			t.Fatalf("failed to unmarshal %s: %s", b, err)
+		}
+		if m != table.money {
+			t.Fatalf("unmarshal %s got %d want %d", b, m, table.money)
+		}
+	}
+	var m drip.Money
+	if err := json.Unmarshal([]byte("1.999"), &m); err == nil {
+		t.Fatalf("failed to error on three decimals")
+	}
+	if err := json.Unmarshal([]byte(`"12.50"`), &m); err != nil || m != 1250 {
+		t.Fatalf("unmarshal quoted money got %d, %v", m, err)
+	}
+	for _, bad := range []string{`"--5"`, `"1.+5"`, `"1.-5"`, `"+5"`, `"1.5a"`, `"-"`, `"1.50`} {
+		if err := m.UnmarshalJSON([]byte(bad)); err == nil {
+			t.Fatalf("failed to error on %s, got %d", bad, m)
+		}
+	}
+}
+
+func TestRecordOrderActivity(t *testing.T) {
+	total := drip.Money(2500)
+	tables := []struct {
+		req  *drip.Order
+		resp *mockResp
+	}{
+		{
+			req: &drip.Order{
+				Provider:   "my_store",
+				Email:      testEmail,
+				Action:     drip.OrderPaid,
+				OrderID:    "456",
+				GrandTotal: &total,
+				Items: []drip.LineItem{
+					{ProductID: "B01J4SWO1G", Name: "Coffee", Price: 1250, Quantity: 2},
+				},
+			},
+			resp: &mockResp{
+				desc:         "failed to record order",
+				hasError:     false,
+				minCodeError: 0,
+			},
+		},
+		{
+			req: &drip.Order{
+				Provider: "my_store",
+				Action:   drip.OrderPaid,
+				OrderID:  "456",
+			},
+			resp: &mockResp{
+				desc:         "failed to error on no email or person id",
+				hasError:     true,
+				minCodeError: 0,
+			},
+		},
+	}
+
+	dripClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/9999999/shopper_activity/order" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		b, _ := ioutil.ReadAll(r.Body)
+		var body map[string]interface{}
+		if err := json.Unmarshal(b, &body); err != nil {
+			t.Errorf("failed to decode body: %s", err)
+		}
+		if body["grand_total"] != 25.0 || body["action"] != "paid" {
+			t.Errorf("unexpected body %s", b)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"request_id":"990c99a7-5cba-42e8-8f36-aec3419a6e9a"}`))
+	})
+	for _, table := range tables {
+		resp, err := dripClient.RecordOrderActivity(table.req)
+		if err != nil && table.resp.hasError != true {
+			t.Fatalf("hasError %s: %s", table.resp.desc, err)
+		}
+		if err == nil && table.resp.hasError {
+			t.Fatalf("hasError %s", table.resp.desc)
+		}
+		if resp != nil && len(resp.Errors) < table.resp.minCodeError {
+			t.Fatalf("minCodeError %s", table.resp.desc)
+		}
+		if resp != nil && resp.RequestID == "" {
+			t.Fatalf("missing request id %s", table.resp.desc)
+		}
+	}
+}
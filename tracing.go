@@ -0,0 +1,61 @@
+package drip
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Span is a tracing span started by a Tracer.
+type Span interface {
+	// SetAttribute sets an attribute. value is a string, int, bool or []string.
+	SetAttribute(key string, value interface{})
+	// RecordError records err and marks the span as failed.
+	RecordError(err error)
+	End()
+}
+
+// Tracer starts spans for Tracing. The dripotel package adapts OpenTelemetry tracers.
+type Tracer interface {
+	Start(ctx context.Context, spanName string) (context.Context, Span)
+}
+
+// Tracing starts a span named after the operation, e.g. "drip.UpdateSubscriber", for every API call.
+// The span is a child of the context passed to WithContext and carries the account ID, HTTP status,
+// retry count, batch size and Drip error codes. Add it before Retry so retries share one span.
+// A nil tracer disables tracing.
+func Tracing(tracer Tracer) Middleware {
+	return func(next Handler) Handler {
+		if tracer == nil {
+			return next
+		}
+		return func(req *Request) (*http.Response, error) {
+			ctx, span := tracer.Start(req.Context, "drip."+req.Operation)
+			defer span.End()
+			req.Context = ctx
+			span.SetAttribute("drip.operation", req.Operation)
+			span.SetAttribute("drip.account_id", req.AccountID)
+			span.SetAttribute("http.request.method", req.Method)
+			span.SetAttribute("url.path", redactEmails(req.Path, true))
+			if req.BatchSize > 0 {
+				span.SetAttribute("drip.batch_size", req.BatchSize)
+			}
+			resp, err := next(req)
+			span.SetAttribute("drip.retry_count", req.Attempt)
+			if err != nil {
+				span.RecordError(err)
+				return resp, err
+			}
+			span.SetAttribute("http.response.status_code", resp.StatusCode)
+			codes := errorCodes(resp)
+			if len(codes) > 0 {
+				span.SetAttribute("drip.error_codes", codes)
+			}
+			if resp.StatusCode >= 400 {
+				span.RecordError(fmt.Errorf("StatusCode(%d) %s", resp.StatusCode, strings.Join(codes, ",")))
+			}
+			return resp, err
+		}
+	}
+}
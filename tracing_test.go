@@ -0,0 +1,103 @@
+package drip_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/dynamite-jobs/drip-go"
+)
+
+type ctxKey string
+
+type fakeSpan struct {
+	name   string
+	parent interface{}
+	attrs  map[string]interface{}
+	errs   []error
+	ended  bool
+}
+
+func (s *fakeSpan) SetAttribute(key string, value interface{}) { s.attrs[key] = value }
+func (s *fakeSpan) RecordError(err error)                      { s.errs = append(s.errs, err) }
+func (s *fakeSpan) End()                                       { s.ended = true }
+
+type fakeTracer struct {
+	spans []*fakeSpan
+}
+
+func (t *fakeTracer) Start(ctx context.Context, spanName string) (context.Context, drip.Span) {
+	span := &fakeSpan{name: spanName, parent: ctx.Value(ctxKey("parent")), attrs: make(map[string]interface{})}
+	t.spans = append(t.spans, span)
+	return ctx, span
+}
+
+func TestTracing(t *testing.T) {
+	var calls int
+	dripClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"errors":[{"code":"unavailable_error","message":"try again"}]}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	})
+	tracer := &fakeTracer{}
+	dripClient.Use(drip.Tracing(tracer), drip.Retry(1, time.Millisecond, time.Second))
+
+	ctx := context.WithValue(context.Background(), ctxKey("parent"), "caller")
+	_, err := dripClient.WithContext(ctx).UpdateBatchSubscribers(&drip.UpdateBatchSubscribersReq{
+		Batches: []drip.SubscribersBatch{
+			{Subscribers: []drip.UpdateSubscriber{{Email: testEmail}, {Email: "other@test.com"}}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to update batch: %s", err)
+	}
+	if len(tracer.spans) != 1 {
+		t.Fatalf("expected one span, got %d", len(tracer.spans))
+	}
+	span := tracer.spans[0]
+	if span.name != "drip.UpdateBatchSubscribers" || span.parent != "caller" || !span.ended {
+		t.Fatalf("unexpected span %+v", span)
+	}
+	want := map[string]interface{}{
+		"drip.account_id":           "9999999",
+		"drip.batch_size":           2,
+		"drip.retry_count":          1,
+		"http.response.status_code": 200,
+	}
+	for key, value := range want {
+		if span.attrs[key] != value {
+			t.Fatalf("attribute %s got %v want %v", key, span.attrs[key], value)
+		}
+	}
+	if len(span.errs) != 0 {
+		t.Fatalf("unexpected errors %v", span.errs)
+	}
+
+	dripClient = newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"errors":[{"code":"presence_error","attribute":"email","message":"Email is required"}]}`))
+	})
+	dripClient.Use(drip.Tracing(tracer))
+	dripClient.TagSubscriber(&drip.TagsReq{Tags: []drip.TagReq{{Tag: "dev"}}})
+	span = tracer.spans[1]
+	if codes, ok := span.attrs["drip.error_codes"].([]string); !ok || len(codes) != 1 || codes[0] != "presence_error" || len(span.errs) != 1 {
+		t.Fatalf("unexpected span %+v", span)
+	}
+}
+
+func TestTracingDisabled(t *testing.T) {
+	dripClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	dripClient.Use(drip.Tracing(nil))
+	if _, err := dripClient.DeleteSubscriber(testEmail); err != nil {
+		t.Fatalf("failed to delete subscriber: %s", err)
+	}
+}
@@ -0,0 +1,33 @@
+package drip
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// User is the owner of the api key.
+type User struct {
+	Email    string `json:"email,omitempty"`
+	Name     string `json:"name,omitempty"`
+	TimeZone string `json:"time_zone,omitempty"`
+}
+
+// UsersResp is a response recieved with users in it.
+type UsersResp struct {
+	StatusCode int         `json:"status_code,omitempty"`
+	Users      []*User     `json:"users,omitempty"`
+	Errors     []CodeError `json:"errors,omitempty"`
+}
+
+// FetchUser fetches the user the api key belongs to.
+// It does not need an account, so it works with a Client from NewWithoutAccount and is a cheap credential check.
+func (c *Client) FetchUser() (*UsersResp, error) {
+	url := fmt.Sprintf("%suser", baseURL)
+	resp := new(UsersResp)
+	httpResp, err := c.do(&Request{Operation: "FetchUser", Method: http.MethodGet, URL: url, Body: nil}, resp)
+	if httpResp == nil {
+		return nil, err
+	}
+	resp.StatusCode = httpResp.StatusCode
+	return resp, err
+}
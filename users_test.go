@@ -0,0 +1,31 @@
+package drip_test
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestFetchUser(t *testing.T) {
+	dripClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v2/user" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if user, _, _ := r.BasicAuth(); user != "testkey" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"errors":[{"code":"authentication_error","message":"You are not authenticated"}]}`))
+			return
+		}
+		w.Write([]byte(`{"users":[{"email":"john@acme.com","name":"John Doe","time_zone":"America/Los_Angeles"}]}`))
+	})
+	resp, err := dripClient.FetchUser()
+	if err != nil {
+		t.Fatalf("failed to fetch user: %s", err)
+	}
+	if len(resp.Errors) != 0 {
+		t.Fatalf("unexpected errors %+v", resp.Errors)
+	}
+	if len(resp.Users) != 1 || resp.Users[0].TimeZone != "America/Los_Angeles" {
+		t.Fatalf("unexpected users %+v", resp.Users)
+	}
+}
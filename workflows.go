@@ -0,0 +1,205 @@
+package drip
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Workflow is an automation workflow.
+type Workflow struct {
+	ID        string    `json:"id,omitempty"`
+	HREF      string    `json:"href,omitempty"`
+	Name      string    `json:"name,omitempty"`
+	Status    string    `json:"status,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	Links     Links     `json:"links,omitempty"`
+}
+
+// WorkflowsResp is a response recieved with workflows in it.
+// List functions have Meta for pagination. StatusCode is included in resp.
+type WorkflowsResp struct {
+	StatusCode int         `json:"status_code,omitempty"`
+	Links      Links       `json:"links,omitempty"`
+	Meta       Meta        `json:"meta,omitempty"`
+	Workflows  []*Workflow `json:"workflows,omitempty"`
+	Errors     []CodeError `json:"errors,omitempty"`
+}
+
+// ListWorkflowsReq is a request for ListWorkflows.
+// Status can be active, draft, paused or all.
+type ListWorkflowsReq struct {
+	Status    string `url:"status,omitempty"`
+	Sort      string `url:"sort,omitempty"`
+	Direction string `url:"direction,omitempty"`
+	Page      *int   `url:"page,omitempty"`
+	PerPage   *int   `url:"per_page,omitempty"`
+}
+
+// ListWorkflows returns a list of workflows.
+func (c *Client) ListWorkflows(req *ListWorkflowsReq) (*WorkflowsResp, error) {
+	url := fmt.Sprintf("%s%s/workflows", baseURL, c.accountID)
+	resp := new(WorkflowsResp)
+	httpResp, err := c.do(&Request{Operation: "ListWorkflows", Method: http.MethodGet, URL: url, Body: req}, resp)
+	if httpResp == nil {
+		return nil, err
+	}
+	resp.StatusCode = httpResp.StatusCode
+	return resp, err
+}
+
+// FetchWorkflow fetches a workflow.
+func (c *Client) FetchWorkflow(workflowID string) (*WorkflowsResp, error) {
+	if workflowID == "" {
+		return nil, ErrInvalidInput
+	}
+	url := fmt.Sprintf("%s%s/workflows/%s", baseURL, c.accountID, workflowID)
+	resp := new(WorkflowsResp)
+	httpResp, err := c.do(&Request{Operation: "FetchWorkflow", Method: http.MethodGet, URL: url, Body: nil}, resp)
+	if httpResp == nil {
+		return nil, err
+	}
+	resp.StatusCode = httpResp.StatusCode
+	return resp, err
+}
+
+// ActivateWorkflow activates a workflow.
+func (c *Client) ActivateWorkflow(workflowID string) (*Response, error) {
+	if workflowID == "" {
+		return nil, ErrInvalidInput
+	}
+	url := fmt.Sprintf("%s%s/workflows/%s/activate", baseURL, c.accountID, workflowID)
+	resp := new(Response)
+	httpResp, err := c.do(&Request{Operation: "ActivateWorkflow", Method: http.MethodPost, URL: url, Body: nil}, resp)
+	if httpResp == nil {
+		return nil, err
+	}
+	resp.StatusCode = httpResp.StatusCode
+	return resp, err
+}
+
+// PauseWorkflow pauses a workflow.
+func (c *Client) PauseWorkflow(workflowID string) (*Response, error) {
+	if workflowID == "" {
+		return nil, ErrInvalidInput
+	}
+	url := fmt.Sprintf("%s%s/workflows/%s/pause", baseURL, c.accountID, workflowID)
+	resp := new(Response)
+	httpResp, err := c.do(&Request{Operation: "PauseWorkflow", Method: http.MethodPost, URL: url, Body: nil}, resp)
+	if httpResp == nil {
+		return nil, err
+	}
+	resp.StatusCode = httpResp.StatusCode
+	return resp, err
+}
+
+// StartWorkflow starts a subscriber on a workflow, creating or updating the subscriber with the given fields.
+// Only the first subscriber in req is used by Drip.
+func (c *Client) StartWorkflow(workflowID string, req *UpdateSubscribersReq) (*SubscribersResp, error) {
+	if workflowID == "" || req == nil || len(req.Subscribers) == 0 {
+		return nil, ErrInvalidInput
+	}
+	if req.Subscribers[0].Email == "" && req.Subscribers[0].ID == "" {
+		return nil, ErrIDorEmailEmpty
+	}
+	if c.CustomFields != nil {
+		if err := c.CustomFields.ValidateSubscribers(req.Subscribers); err != nil {
+			return nil, err
+		}
+	}
+	url := fmt.Sprintf("%s%s/workflows/%s/subscribers", baseURL, c.accountID, workflowID)
+	resp := new(SubscribersResp)
+	httpResp, err := c.do(&Request{Operation: "StartWorkflow", Method: http.MethodPost, URL: url, Body: req}, resp)
+	if httpResp == nil {
+		return nil, err
+	}
+	resp.StatusCode = httpResp.StatusCode
+	return resp, err
+}
+
+// RemoveFromWorkflow removes a subscriber from a workflow.
+func (c *Client) RemoveFromWorkflow(workflowID, idOrEmail string) (*Response, error) {
+	if workflowID == "" {
+		return nil, ErrInvalidInput
+	}
+	if idOrEmail == "" {
+		return nil, ErrIDorEmailEmpty
+	}
+	url := fmt.Sprintf("%s%s/workflows/%s/subscribers/%s", baseURL, c.accountID, workflowID, idOrEmail)
+	resp := new(Response)
+	httpResp, err := c.do(&Request{Operation: "RemoveFromWorkflow", Method: http.MethodDelete, URL: url, Body: nil}, resp)
+	if httpResp == nil {
+		return nil, err
+	}
+	resp.StatusCode = httpResp.StatusCode
+	return resp, err
+}
+
+// WorkflowTrigger is a trigger that starts subscribers on a workflow.
+// TriggerType is e.g. "performed_an_action" whose Properties hold the action name sent to RecordEvent.
+type WorkflowTrigger struct {
+	ID          string                 `json:"id,omitempty"`
+	HREF        string                 `json:"href,omitempty"`
+	Provider    string                 `json:"provider,omitempty"`
+	TriggerType string                 `json:"trigger_type,omitempty"`
+	Properties  map[string]interface{} `json:"properties,omitempty"`
+	Links       Links                  `json:"links,omitempty"`
+}
+
+// WorkflowTriggersReq is a request for CreateWorkflowTrigger and UpdateWorkflowTrigger.
+type WorkflowTriggersReq struct {
+	Triggers []WorkflowTrigger `json:"triggers,omitempty"`
+}
+
+// WorkflowTriggersResp is a response recieved with workflow triggers in it.
+type WorkflowTriggersResp struct {
+	StatusCode int                `json:"status_code,omitempty"`
+	Links      Links              `json:"links,omitempty"`
+	Triggers   []*WorkflowTrigger `json:"triggers,omitempty"`
+	Errors     []CodeError        `json:"errors,omitempty"`
+}
+
+// ListWorkflowTriggers returns the triggers of a workflow.
+func (c *Client) ListWorkflowTriggers(workflowID string) (*WorkflowTriggersResp, error) {
+	if workflowID == "" {
+		return nil, ErrInvalidInput
+	}
+	url := fmt.Sprintf("%s%s/workflows/%s/triggers", baseURL, c.accountID, workflowID)
+	resp := new(WorkflowTriggersResp)
+	httpResp, err := c.do(&Request{Operation: "ListWorkflowTriggers", Method: http.MethodGet, URL: url, Body: nil}, resp)
+	if httpResp == nil {
+		return nil, err
+	}
+	resp.StatusCode = httpResp.StatusCode
+	return resp, err
+}
+
+// CreateWorkflowTrigger adds a trigger to a workflow.
+func (c *Client) CreateWorkflowTrigger(workflowID string, req *WorkflowTriggersReq) (*WorkflowTriggersResp, error) {
+	if workflowID == "" || req == nil || len(req.Triggers) == 0 {
+		return nil, ErrInvalidInput
+	}
+	url := fmt.Sprintf("%s%s/workflows/%s/triggers", baseURL, c.accountID, workflowID)
+	resp := new(WorkflowTriggersResp)
+	httpResp, err := c.do(&Request{Operation: "CreateWorkflowTrigger", Method: http.MethodPost, URL: url, Body: req}, resp)
+	if httpResp == nil {
+		return nil, err
+	}
+	resp.StatusCode = httpResp.StatusCode
+	return resp, err
+}
+
+// UpdateWorkflowTrigger updates a trigger of a workflow.
+func (c *Client) UpdateWorkflowTrigger(workflowID, triggerID string, req *WorkflowTriggersReq) (*WorkflowTriggersResp, error) {
+	if workflowID == "" || triggerID == "" || req == nil || len(req.Triggers) == 0 {
+		return nil, ErrInvalidInput
+	}
+	url := fmt.Sprintf("%s%s/workflows/%s/triggers/%s", baseURL, c.accountID, workflowID, triggerID)
+	resp := new(WorkflowTriggersResp)
+	httpResp, err := c.do(&Request{Operation: "UpdateWorkflowTrigger", Method: http.MethodPut, URL: url, Body: req}, resp)
+	if httpResp == nil {
+		return nil, err
+	}
+	resp.StatusCode = httpResp.StatusCode
+	return resp, err
+}
@@ -0,0 +1,162 @@
+package drip_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/dynamite-jobs/drip-go"
+)
+
+func TestListWorkflows(t *testing.T) {
+	dripClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v2/9999999/workflows" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.URL.Query().Get("status") != "active" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"workflows":[{"id":"444","name":"Welcome","status":"active"}],"meta":{"page":1,"count":1,"total_pages":1,"total_count":1}}`))
+	})
+	resp, err := dripClient.ListWorkflows(&drip.ListWorkflowsReq{Status: "active"})
+	if err != nil {
+		t.Fatalf("failed to list workflows: %s", err)
+	}
+	if len(resp.Workflows) != 1 || resp.Workflows[0].Name != "Welcome" {
+		t.Fatalf("unexpected workflows %+v", resp.Workflows)
+	}
+	if resp.Meta.TotalCount != 1 {
+		t.Fatalf("unexpected meta %+v", resp.Meta)
+	}
+}
+
+func TestPauseWorkflow(t *testing.T) {
+	tables := []struct {
+		workflowID string
+		resp       *mockResp
+	}{
+		{
+			workflowID: "444",
+			resp: &mockResp{
+				desc:         "failed to pause workflow",
+				hasError:     false,
+				minCodeError: 0,
+			},
+		},
+		{
+			workflowID: "",
+			resp: &mockResp{
+				desc:         "failed to error on no workflow id",
+				hasError:     true,
+				minCodeError: 0,
+			},
+		},
+	}
+
+	dripClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v2/9999999/workflows/444/pause" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	for _, table := range tables {
+		resp, err := dripClient.PauseWorkflow(table.workflowID)
+		if err != nil && table.resp.hasError != true {
+			t.Fatalf("hasError %s: %s", table.resp.desc, err)
+		}
+		if err == nil && table.resp.hasError {
+			t.Fatalf("hasError %s", table.resp.desc)
+		}
+		if resp != nil && len(resp.Errors) < table.resp.minCodeError {
+			t.Fatalf("minCodeError %s", table.resp.desc)
+		}
+	}
+}
+
+func TestStartWorkflow(t *testing.T) {
+	tables := []struct {
+		workflowID string
+		req        *drip.UpdateSubscribersReq
+		resp       *mockSubscribersResp
+	}{
+		{
+			workflowID: "444",
+			req: &drip.UpdateSubscribersReq{
+				Subscribers: []drip.UpdateSubscriber{
+					{Email: testEmail, Tags: []string{"dev"}},
+				},
+			},
+			resp: &mockSubscribersResp{
+				desc:         "failed to start workflow",
+				minSubs:      1,
+				hasError:     false,
+				minCodeError: 0,
+			},
+		},
+		{
+			workflowID: "444",
+			req: &drip.UpdateSubscribersReq{
+				Subscribers: []drip.UpdateSubscriber{
+					{Tags: []string{"dev"}},
+				},
+			},
+			resp: &mockSubscribersResp{
+				desc:         "failed to error on no id or email",
+				minSubs:      0,
+				hasError:     true,
+				minCodeError: 0,
+			},
+		},
+	}
+
+	dripClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v2/9999999/workflows/444/subscribers" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"subscribers":[{"id":"z1togz2hcjrkpp5treip","email":"` + testEmail + `"}]}`))
+	})
+	for _, table := range tables {
+		resp, err := dripClient.StartWorkflow(table.workflowID, table.req)
+		if err != nil && table.resp.hasError != true {
+			t.Fatalf("hasError %s: %s", table.resp.desc, err)
+		}
+		if err == nil && table.resp.hasError {
+			t.Fatalf("hasError %s", table.resp.desc)
+		}
+		if resp != nil && len(resp.Errors) < table.resp.minCodeError {
+			t.Fatalf("minCodeError %s", table.resp.desc)
+		}
+		if resp != nil && len(resp.Subscribers) < table.resp.minSubs {
+			t.Fatalf("minSubs %s", table.resp.desc)
+		}
+	}
+}
+
+func TestUpdateWorkflowTrigger(t *testing.T) {
+	dripClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/v2/9999999/workflows/444/triggers/555" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"triggers":[{"id":"555","provider":"drip","trigger_type":"performed_an_action","properties":{"action":"Signed up"}}]}`))
+	})
+	resp, err := dripClient.UpdateWorkflowTrigger("444", "555", &drip.WorkflowTriggersReq{
+		Triggers: []drip.WorkflowTrigger{
+			{
+				Provider:    "drip",
+				TriggerType: "performed_an_action",
+				Properties:  map[string]interface{}{"action": "Signed up"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to update workflow trigger: %s", err)
+	}
+	if len(resp.Triggers) != 1 || resp.Triggers[0].Properties["action"] != "Signed up" {
+		t.Fatalf("unexpected triggers %+v", resp.Triggers)
+	}
+	if _, err := dripClient.UpdateWorkflowTrigger("444", "", &drip.WorkflowTriggersReq{}); err != drip.ErrInvalidInput {
+		t.Fatalf("failed to get ErrInvalidInput: %v", err)
+	}
+}